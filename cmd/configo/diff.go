@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+
+	configo "github.com/jxsl13/simple-configo"
+)
+
+const secretMask = "***"
+
+var (
+	// keys that look like they contain secrets, their values are masked in diffs
+	secretKeyRegex = regexp.MustCompile(`(?i)(pass|secret|token|key|credential|private)`)
+)
+
+func setupDiff(flags *flag.FlagSet) {
+	flags.Bool("show-secrets", false, "do not mask the values of secret keys")
+}
+
+// runDiff compares two env files and prints the added (+), removed (-) and changed (~) keys.
+// The exit code is 1 in case that the files differ.
+func runDiff(flags *flag.FlagSet, args []string, stdout io.Writer) (int, error) {
+	if len(args) != 2 {
+		return exitUsage, errUsage
+	}
+	showSecrets := flags.Lookup("show-secrets").Value.(flag.Getter).Get().(bool)
+
+	a, err := configo.ReadEnvFile(args[0])
+	if err != nil {
+		return exitUsage, err
+	}
+	b, err := configo.ReadEnvFile(args[1])
+	if err != nil {
+		return exitUsage, err
+	}
+
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, found := a[k]; !found {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	code := exitOK
+	for _, k := range keys {
+		oldValue, inA := a[k]
+		newValue, inB := b[k]
+		if inA && inB && oldValue == newValue {
+			continue
+		}
+		code = exitFailure
+
+		mask := !showSecrets && isSecret(k, oldValue, newValue)
+		show := func(value string) string {
+			if mask {
+				return secretMask
+			}
+			return strconv.Quote(value)
+		}
+
+		switch {
+		case !inA:
+			fmt.Fprintf(stdout, "+ %s=%s\n", k, show(newValue))
+		case !inB:
+			fmt.Fprintf(stdout, "- %s=%s\n", k, show(oldValue))
+		default:
+			fmt.Fprintf(stdout, "~ %s=%s -> %s\n", k, show(oldValue), show(newValue))
+		}
+	}
+	return code, nil
+}
+
+// isSecret reports whether the key looks like a secret or any of the values is encrypted.
+func isSecret(key string, values ...string) bool {
+	if secretKeyRegex.MatchString(key) {
+		return true
+	}
+	for _, value := range values {
+		if configo.IsEncrypted(value) {
+			return true
+		}
+	}
+	return false
+}
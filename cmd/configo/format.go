@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+func setupFmt(flags *flag.FlagSet) {
+	flags.Bool("w", false, "write the result back into the files instead of stdout")
+}
+
+// runFmt formats the env files and either prints them or writes them back.
+func runFmt(flags *flag.FlagSet, args []string, stdout io.Writer) (int, error) {
+	if len(args) == 0 {
+		return exitUsage, errUsage
+	}
+	write := flags.Lookup("w").Value.(flag.Getter).Get().(bool)
+
+	for _, filePath := range args {
+		content, err := internal.Load(filePath)
+		if err != nil {
+			return exitFailure, err
+		}
+		formatted, err := formatEnvFile(filePath, content)
+		if err != nil {
+			return exitFailure, err
+		}
+
+		if !write {
+			io.WriteString(stdout, formatted)
+			continue
+		}
+		if formatted == content {
+			continue
+		}
+		err = internal.WriteFileAtomic(filePath, []byte(formatted), 0666)
+		if err != nil {
+			return exitFailure, err
+		}
+	}
+	return exitOK, nil
+}
+
+// envEntry is a key value pair with the comment lines above it.
+type envEntry struct {
+	comments []string
+	key      string
+}
+
+// formatEnvFile sorts the keys between the include directives and normalises their quoting.
+// Comment lines above a key are moved together with the key, comment blocks that are separated from the
+// first key of a section by a blank line stay at the top of the section and trailing comments stay at the end.
+// The include directives are kept in place, because the order of includes and keys defines which values win.
+// The signature comment is a trailing comment and stays valid, as the signature does not depend on
+// the order of the keys between the include directives, their quoting or comments.
+func formatEnvFile(filePath, content string) (string, error) {
+	lines, errs := scanLines(filePath, content)
+	if len(errs) > 0 {
+		return "", errs[0]
+	}
+
+	var (
+		buf     bytes.Buffer
+		groups  []string
+		header  []string
+		pending []string
+		entries []envEntry
+		block   strings.Builder
+	)
+	writeGroup := func(lines ...string) {
+		if len(lines) > 0 {
+			groups = append(groups, strings.Join(lines, "\n"))
+		}
+	}
+
+	// flush writes the current section, which is the part between two include directives
+	flush := func() error {
+		env, err := godotenv.Unmarshal(block.String())
+		if err != nil {
+			return err
+		}
+		block.Reset()
+
+		writeGroup(header...)
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].key < entries[j].key
+		})
+		var plain []string
+		for _, entry := range entries {
+			line, err := godotenv.Marshal(map[string]string{entry.key: env[entry.key]})
+			if err != nil {
+				return err
+			}
+			if len(entry.comments) == 0 {
+				plain = append(plain, line)
+				continue
+			}
+			writeGroup(plain...)
+			plain = nil
+			writeGroup(append(entry.comments, line)...)
+		}
+		writeGroup(plain...)
+		writeGroup(pending...)
+
+		header, pending, entries = nil, nil, nil
+		return nil
+	}
+
+	for _, line := range lines {
+		switch line.kind {
+		case lineBlank:
+			// comments above the first key that are not directly above it
+			if len(entries) == 0 {
+				header = append(header, pending...)
+				pending = nil
+			}
+		case lineComment:
+			pending = append(pending, line.text)
+		case lineKeyValue:
+			block.WriteString(line.text + "\n")
+			entries = append(entries, envEntry{comments: pending, key: line.key})
+			pending = nil
+		case lineInclude:
+			if err := flush(); err != nil {
+				return "", err
+			}
+			writeGroup(line.text)
+		}
+	}
+	if err := flush(); err != nil {
+		return "", err
+	}
+
+	for idx, group := range groups {
+		if idx > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(group + "\n")
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+type lineKind int
+
+const (
+	lineBlank lineKind = iota
+	lineComment
+	lineInclude
+	lineKeyValue
+)
+
+// envLine is a single line of an env file.
+type envLine struct {
+	number int
+	kind   lineKind
+	text   string
+	key    string
+}
+
+// lineError is a syntax error in a line of an env file.
+type lineError struct {
+	filePath string
+	line     int
+	msg      string
+}
+
+func (le *lineError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", le.filePath, le.line, le.msg)
+}
+
+// scanLines splits the content of an env file into lines and determines the key of every key value line.
+// All syntax errors and duplicate keys are returned.
+func scanLines(filePath, content string) ([]envLine, []error) {
+	var (
+		lines []envLine
+		errs  []error
+		seen  = make(map[string]int)
+	)
+	content = strings.TrimSuffix(content, "\n")
+	for idx, text := range strings.Split(content, "\n") {
+		text = strings.TrimRight(text, "\r")
+		line := envLine{number: idx + 1, text: strings.TrimSpace(text)}
+
+		switch {
+		case line.text == "":
+			line.kind = lineBlank
+		case isIncludeDirective(line.text):
+			line.kind = lineInclude
+		case strings.HasPrefix(line.text, "#"):
+			line.kind = lineComment
+		default:
+			line.kind = lineKeyValue
+			env, err := godotenv.Unmarshal(text)
+			if err != nil {
+				errs = append(errs, &lineError{filePath, line.number, err.Error()})
+				continue
+			}
+			for key := range env {
+				line.key = key
+			}
+			if line.key == "" {
+				errs = append(errs, &lineError{filePath, line.number, "missing key"})
+				continue
+			}
+			if first, found := seen[line.key]; found {
+				errs = append(errs, &lineError{filePath, line.number, fmt.Sprintf("duplicate key '%s', first defined in line %d", line.key, first)})
+				continue
+			}
+			seen[line.key] = line.number
+		}
+		lines = append(lines, line)
+	}
+	return lines, errs
+}
+
+// isIncludeDirective reports whether the line is an #include or #include-optional directive.
+func isIncludeDirective(line string) bool {
+	return strings.HasPrefix(line, "#include ") || strings.HasPrefix(line, "#include-optional ")
+}
@@ -0,0 +1,110 @@
+// Command configo works with env files without writing any Go code.
+//
+//	configo validate FILE...            check syntax and duplicate keys
+//	configo diff [-show-secrets] A B    show added, removed and changed keys
+//	configo merge [-o OUT] FILE...      layer files, later files override earlier ones
+//	configo fmt [-w] FILE...            sort keys, normalise quoting and keep comments
+//	configo get FILE KEY                print the value of a key
+//	configo set FILE KEY VALUE          change the value of a key
+//
+// The files are read and written with the same dotenv reader and writer that the library uses,
+// which means that include directives, signatures and encrypted values are supported.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+var (
+	errUsage = errors.New("invalid usage")
+)
+
+// command is a subcommand of the configo tool.
+type command struct {
+	name  string
+	args  string
+	short string
+	run   func(flags *flag.FlagSet, args []string, stdout io.Writer) (int, error)
+	// setup defines the flags of the command
+	setup func(flags *flag.FlagSet)
+}
+
+var commands = []command{
+	{name: "validate", args: "FILE...", short: "check syntax and duplicate keys", run: runValidate},
+	{name: "diff", args: "[-show-secrets] A B", short: "show added, removed and changed keys", run: runDiff, setup: setupDiff},
+	{name: "merge", args: "[-o OUT] FILE...", short: "layer files, later files override earlier ones", run: runMerge, setup: setupMerge},
+	{name: "fmt", args: "[-w] FILE...", short: "sort keys, normalise quoting and keep comments", run: runFmt, setup: setupFmt},
+	{name: "get", args: "FILE KEY", short: "print the value of a key", run: runGet},
+	{name: "set", args: "FILE KEY VALUE", short: "change the value of a key", run: runSet},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the subcommand and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	name := args[0]
+	if name == "-h" || name == "--help" || name == "help" {
+		usage(stdout)
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		flags := flag.NewFlagSet("configo "+cmd.name, flag.ContinueOnError)
+		flags.SetOutput(stderr)
+		flags.Usage = func() {
+			fmt.Fprintf(stderr, "Usage: configo %s %s\n", cmd.name, cmd.args)
+			flags.PrintDefaults()
+		}
+		if cmd.setup != nil {
+			cmd.setup(flags)
+		}
+		if err := flags.Parse(args[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return exitOK
+			}
+			return exitUsage
+		}
+
+		code, err := cmd.run(flags, flags.Args(), stdout)
+		if errors.Is(err, errUsage) {
+			flags.Usage()
+			return exitUsage
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "configo %s: %v\n", cmd.name, err)
+		}
+		return code
+	}
+
+	fmt.Fprintf(stderr, "configo: unknown command '%s'\n", name)
+	usage(stderr)
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: configo <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s%s\n", cmd.name, cmd.short)
+	}
+}
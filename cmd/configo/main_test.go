@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runConfigo(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	filePath := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(content), 0600))
+	return filePath
+}
+
+func readFile(t *testing.T, filePath string) string {
+	t.Helper()
+	b, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	valid := writeFile(t, dir, "valid.env", "# comment\nHOST=localhost\nexport PORT=80\n")
+	invalid := writeFile(t, dir, "invalid.env", "HOST=localhost\nbroken line\nPORT=80\nHOST=remote\n")
+
+	code, stdout, _ := runConfigo(t, "validate", valid)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stdout)
+
+	code, stdout, _ = runConfigo(t, "validate", valid, invalid)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, invalid+":2: Can't separate key from value\n"+
+		invalid+":4: duplicate key 'HOST', first defined in line 1\n", stdout)
+
+	code, _, _ = runConfigo(t, "validate")
+	assert.Equal(t, 2, code)
+}
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	a := writeFile(t, dir, "a.env", "HOST=localhost\nPORT=80\nDB_PASSWORD=old\nDEBUG=true\n")
+	b := writeFile(t, dir, "b.env", "HOST=remote\nPORT=80\nDB_PASSWORD=new\nAPI_TOKEN=abc\n")
+
+	code, stdout, _ := runConfigo(t, "diff", a, b)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, `+ API_TOKEN=***
+~ DB_PASSWORD=*** -> ***
+- DEBUG="true"
+~ HOST="localhost" -> "remote"
+`, stdout)
+
+	code, stdout, _ = runConfigo(t, "diff", "-show-secrets", a, b)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, `~ DB_PASSWORD="old" -> "new"`)
+
+	code, stdout, _ = runConfigo(t, "diff", a, a)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stdout)
+}
+
+func TestMerge(t *testing.T) {
+	dir := t.TempDir()
+	a := writeFile(t, dir, "a.env", "HOST=localhost\nPORT=80\n")
+	b := writeFile(t, dir, "b.env", "PORT=8080\nDEBUG=true\n")
+
+	code, stdout, _ := runConfigo(t, "merge", a, b)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "DEBUG=\"true\"\nHOST=\"localhost\"\nPORT=\"8080\"\n", stdout)
+
+	out := filepath.Join(dir, "out.env")
+	code, _, _ = runConfigo(t, "merge", "-o", out, b, a)
+	assert.Equal(t, 0, code)
+	env, err := configo.ReadEnvFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"DEBUG": "true", "HOST": "localhost", "PORT": "80"}, env)
+}
+
+func TestFmt(t *testing.T) {
+	dir := t.TempDir()
+	filePath := writeFile(t, dir, "app.env", `# application settings
+
+# the port
+PORT=80
+HOST='localhost'
+#include base.env
+ZONE=eu # inline
+   ALPHA = "a b"
+# trailing
+`)
+	writeFile(t, dir, "base.env", "BASE=1\n")
+
+	expected := `# application settings
+
+HOST="localhost"
+
+# the port
+PORT="80"
+
+#include base.env
+
+ALPHA="a b"
+ZONE="eu"
+
+# trailing
+`
+	code, stdout, _ := runConfigo(t, "fmt", filePath)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, expected, stdout)
+
+	before, err := configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+
+	code, _, _ = runConfigo(t, "fmt", "-w", filePath)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, expected, readFile(t, filePath))
+
+	after, err := configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	// formatting is idempotent
+	code, stdout, _ = runConfigo(t, "fmt", filePath)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, expected, stdout)
+}
+
+func TestFmtSigned(t *testing.T) {
+	require.NoError(t, os.Setenv(configo.SignatureKeyEnvKey, "signing key"))
+	defer os.Unsetenv(configo.SignatureKeyEnvKey)
+
+	filePath := writeFile(t, t.TempDir(), "app.env", "# b\nB=2\nA='1'\n")
+	require.NoError(t, configo.SignEnvFile(filePath))
+
+	code, _, _ := runConfigo(t, "fmt", "-w", filePath)
+	assert.Equal(t, 0, code)
+	assert.NoError(t, configo.VerifyEnvFile(filePath))
+}
+
+func TestGetSet(t *testing.T) {
+	dir := t.TempDir()
+	filePath := writeFile(t, dir, "app.env", "HOST=localhost\n")
+
+	code, stdout, _ := runConfigo(t, "get", filePath, "HOST")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "localhost\n", stdout)
+
+	code, _, stderr := runConfigo(t, "get", filePath, "PORT")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "key 'PORT' not found")
+
+	code, _, _ = runConfigo(t, "set", filePath, "PORT", "8080")
+	assert.Equal(t, 0, code)
+	code, stdout, _ = runConfigo(t, "get", filePath, "PORT")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "8080\n", stdout)
+
+	// no temporary files are left behind
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestSetKeepsLines(t *testing.T) {
+	dir := t.TempDir()
+	filePath := writeFile(t, dir, "app.env", "# database host\nexport DB_HOST=localhost\n\n# port\n  DB_PORT=5432\n#include other.env\n# end\n")
+
+	code, _, stderr := runConfigo(t, "set", filePath, "DB_PORT", "6000")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "# database host\nexport DB_HOST=localhost\n\n# port\n  DB_PORT=\"6000\"\n#include other.env\n# end\n", readFile(t, filePath))
+
+	// missing keys are appended after the last include directive
+	code, _, stderr = runConfigo(t, "set", filePath, "DB_USER", "app")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "# database host\nexport DB_HOST=localhost\n\n# port\n  DB_PORT=\"6000\"\n#include other.env\nDB_USER=\"app\"\n# end\n", readFile(t, filePath))
+
+	code, _, stderr = runConfigo(t, "set", filePath, "DB_HOST", "remote")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, readFile(t, filePath), "# database host\nexport DB_HOST=\"remote\"\n\n")
+
+	filePath = filepath.Join(dir, "new", "app.env")
+	code, _, stderr = runConfigo(t, "set", filePath, "HOST", "localhost")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "HOST=\"localhost\"\n", readFile(t, filePath))
+}
+
+func TestSetSigned(t *testing.T) {
+	require.NoError(t, os.Setenv(configo.SignatureKeyEnvKey, "signing key"))
+	defer os.Unsetenv(configo.SignatureKeyEnvKey)
+
+	filePath := writeFile(t, t.TempDir(), "app.env", "# host\nHOST=localhost\n")
+	require.NoError(t, configo.SignEnvFile(filePath))
+
+	code, _, stderr := runConfigo(t, "set", filePath, "HOST", "remote")
+	require.Equal(t, 0, code, stderr)
+	assert.NoError(t, configo.VerifyEnvFile(filePath))
+	assert.Contains(t, readFile(t, filePath), "# host\nHOST=\"remote\"\n")
+
+	// signed files cannot be changed without the key
+	require.NoError(t, os.Unsetenv(configo.SignatureKeyEnvKey))
+	code, _, stderr = runConfigo(t, "set", filePath, "HOST", "other")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "is signed")
+}
+
+func TestUnknownCommand(t *testing.T) {
+	code, _, stderr := runConfigo(t, "unknown")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "unknown command 'unknown'")
+
+	code, stdout, _ := runConfigo(t, "help")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "validate")
+}
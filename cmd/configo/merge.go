@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/joho/godotenv"
+	configo "github.com/jxsl13/simple-configo"
+)
+
+func setupMerge(flags *flag.FlagSet) {
+	flags.String("o", "", "write the result into this env file instead of stdout")
+}
+
+// runMerge layers the env files in order, values of later files override the values of earlier files.
+func runMerge(flags *flag.FlagSet, args []string, stdout io.Writer) (int, error) {
+	if len(args) == 0 {
+		return exitUsage, errUsage
+	}
+	out := flags.Lookup("o").Value.String()
+
+	env := make(map[string]string)
+	for _, filePath := range args {
+		fileMap, err := configo.ReadEnvFile(filePath)
+		if err != nil {
+			return exitFailure, err
+		}
+		for k, v := range fileMap {
+			env[k] = v
+		}
+	}
+
+	if out != "" {
+		err := configo.WriteEnvFile(env, out)
+		if err != nil {
+			return exitFailure, err
+		}
+		return exitOK, nil
+	}
+
+	content, err := godotenv.Marshal(env)
+	if err != nil {
+		return exitFailure, err
+	}
+	if content != "" {
+		fmt.Fprintln(stdout, content)
+	}
+	return exitOK, nil
+}
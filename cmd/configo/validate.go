@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+// runValidate checks the syntax of the files and reports duplicate keys.
+// Afterwards the files are read like the library reads them, which resolves includes and verifies signatures.
+func runValidate(_ *flag.FlagSet, args []string, stdout io.Writer) (int, error) {
+	if len(args) == 0 {
+		return exitUsage, errUsage
+	}
+
+	code := exitOK
+	for _, filePath := range args {
+		content, err := internal.Load(filePath)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: %v\n", filePath, err)
+			code = exitFailure
+			continue
+		}
+
+		_, errs := scanLines(filePath, content)
+		if len(errs) == 0 {
+			_, err = configo.ReadEnvFile(filePath)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", filePath, err))
+			}
+		}
+		for _, err := range errs {
+			fmt.Fprintln(stdout, err)
+		}
+		if len(errs) > 0 {
+			code = exitFailure
+		}
+	}
+	return code, nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+// runGet prints the value of the key. Included env files are resolved.
+// The exit code is 1 in case that the key is not set.
+func runGet(_ *flag.FlagSet, args []string, stdout io.Writer) (int, error) {
+	if len(args) != 2 {
+		return exitUsage, errUsage
+	}
+	filePath, key := args[0], args[1]
+
+	env, err := configo.ReadEnvFile(filePath)
+	if err != nil {
+		return exitFailure, err
+	}
+	value, found := env[key]
+	if !found {
+		return exitFailure, fmt.Errorf("%s: key '%s' not found", filePath, key)
+	}
+	fmt.Fprintln(stdout, value)
+	return exitOK, nil
+}
+
+// runSet changes the value of the key. Only the line of the key is replaced, comments, blank lines
+// and all other lines are kept as they are. Missing keys are appended after the last key or include directive.
+// The file is created in case it does not exist and replaced atomically otherwise.
+// Signed files are signed again, which requires the signature key.
+func runSet(_ *flag.FlagSet, args []string, _ io.Writer) (int, error) {
+	if len(args) != 3 {
+		return exitUsage, errUsage
+	}
+	filePath, key, value := args[0], args[1], args[2]
+
+	content := ""
+	if internal.Exists(filePath) {
+		err := configo.VerifyEnvFile(filePath)
+		switch {
+		case err == nil, errors.Is(err, configo.ErrMissingSignature):
+		case errors.Is(err, configo.ErrMissingSignatureKey):
+			return exitFailure, fmt.Errorf("%s is signed, %s is required in order to sign it again", filePath, configo.SignatureKeyEnvKey)
+		default:
+			return exitFailure, err
+		}
+
+		content, err = internal.Load(filePath)
+		if err != nil {
+			return exitFailure, err
+		}
+	}
+
+	updated, err := setEnvValue(filePath, content, key, value)
+	if err != nil {
+		return exitFailure, err
+	}
+	err = internal.MkdirAll(filePath)
+	if err != nil {
+		return exitFailure, err
+	}
+	err = internal.WriteFileAtomic(filePath, []byte(updated), 0666)
+	if err != nil {
+		return exitFailure, err
+	}
+
+	if os.Getenv(configo.SignatureKeyEnvKey) != "" {
+		err = configo.SignEnvFile(filePath)
+		if err != nil {
+			return exitFailure, err
+		}
+	}
+	return exitOK, nil
+}
+
+// setEnvValue replaces the line of the key in the content or appends the key after the last key
+// or include directive. The indentation and the export prefix of the replaced line are kept.
+func setEnvValue(filePath, content, key, value string) (string, error) {
+	lines, errs := scanLines(filePath, content)
+	if len(errs) > 0 {
+		return "", errs[0]
+	}
+	keyValue, err := godotenv.Marshal(map[string]string{key: value})
+	if err != nil {
+		return "", err
+	}
+
+	raw := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	insert := -1
+	for _, line := range lines {
+		idx := line.number - 1
+		if line.kind == lineKeyValue && line.key == key {
+			text := raw[idx]
+			prefix := text[:len(text)-len(strings.TrimLeft(text, " \t"))]
+			if strings.HasPrefix(line.text, "export ") {
+				prefix += "export "
+			}
+			raw[idx] = prefix + keyValue
+			return strings.Join(raw, "\n") + "\n", nil
+		}
+		if line.kind == lineKeyValue || line.kind == lineInclude {
+			insert = idx + 1
+		}
+	}
+
+	switch {
+	case content == "":
+		raw = []string{keyValue}
+	case insert < 0:
+		raw = append(raw, keyValue)
+	default:
+		raw = append(raw[:insert], append([]string{keyValue}, raw[insert:]...)...)
+	}
+	return strings.Join(raw, "\n") + "\n", nil
+}
@@ -0,0 +1,234 @@
+package configo
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUnknownCommand is returned when the argument after the flags of a command is not one of its subcommands.
+	ErrUnknownCommand = errors.New("unknown command")
+	// ErrUnexpectedArgument is returned when a command without subcommands is followed by further arguments.
+	ErrUnexpectedArgument = errors.New("unexpected argument")
+)
+
+// Command is a node of a command tree, e.g. app serve or app db migrate
+// The root command represents the application itself, its Configs are the global options.
+// The options of a command are passed after the name of the command and before the name of the subcommand:
+//
+//	app --verbose serve --port 8080
+//
+// Every command has its own help text that is printed with -h or --help.
+type Command struct {
+	Name        string
+	Description string
+	Configs     []Config
+	Commands    []*Command
+}
+
+// ParseFlags parses the args (usually os.Args[1:]) and returns the selected command.
+// The options of all commands on the path to the selected command are parsed.
+// The files of the built-in config flag are parsed as lowest layer, see ConfigFlag.
+func (c *Command) ParseFlags(args []string) (*Command, error) {
+	path, cl, err := c.parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	fileMap, provenance, err := readEnvFiles(cl.files)
+	if err != nil {
+		return nil, err
+	}
+	return path[len(path)-1], parseLayers(sourceLayers{
+		fileMap:        fileMap,
+		fileProvenance: provenance,
+		flagMap:        cl.flagMap,
+	}, cl.printConfig, commandConfigs(path)...)
+}
+
+// ParseEnvOrFlags parses the environment and the args (usually os.Args[1:]) and returns the selected command.
+// Flag values override the values of the environment.
+// The options of all commands on the path to the selected command are parsed.
+// The files of the built-in config flag are parsed as lowest layer, see ConfigFlag.
+func (c *Command) ParseEnvOrFlags(args []string) (*Command, error) {
+	path, cl, err := c.parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	fileMap, provenance, err := readEnvFiles(cl.files)
+	if err != nil {
+		return nil, err
+	}
+	return path[len(path)-1], parseLayers(sourceLayers{
+		fileMap:        fileMap,
+		fileProvenance: provenance,
+		env:            GetEnv(),
+		flagMap:        cl.flagMap,
+	}, cl.printConfig, commandConfigs(path)...)
+}
+
+// ParseEnvFileOrEnvOrFlags parses the env file, the environment and the args (usually os.Args[1:]) and
+// returns the selected command. Environment values override the values of the env file and flag values
+// override both of them. A missing env file is skipped.
+// The files of the built-in config flag replace the env file, see ConfigFlag.
+// The options of all commands on the path to the selected command are parsed.
+func (c *Command) ParseEnvFileOrEnvOrFlags(filePathOrEnvKey string, args []string) (*Command, error) {
+	path, cl, err := c.parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	env := GetEnv()
+	fileMap, provenance, err := readEnvFileLayer(env, filePathOrEnvKey, cl.files)
+	if err != nil {
+		return nil, err
+	}
+	return path[len(path)-1], parseLayers(sourceLayers{
+		fileMap:        fileMap,
+		fileProvenance: provenance,
+		env:            env,
+		flagMap:        cl.flagMap,
+	}, cl.printConfig, commandConfigs(path)...)
+}
+
+// parseArgs parses the flags of every command and selects the subcommands by name.
+// It returns the path from the root command to the selected command, the combined flag values
+// and the values of the built-in global flags of the root command.
+func (c *Command) parseArgs(args []string) ([]*Command, *commandLine, error) {
+	var (
+		path = []*Command{c}
+		cl   = &commandLine{flagMap: make(map[string]string)}
+	)
+	for {
+		cmd := path[len(path)-1]
+		root := len(path) == 1
+		flags, values, err := newFlagSet(commandPath(path), flag.ContinueOnError, root, cmd.Configs...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("command '%s': %w", commandPath(path), err)
+		}
+		setCommandUsage(flags, path)
+
+		m, err := parseFlagSet(flags, values, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		cl.flagMap = update(cl.flagMap, m)
+		if root {
+			cl.files = configFiles(flags)
+			cl.printConfig = printConfigFormat(flags)
+		}
+
+		args = flags.Args()
+		if len(args) == 0 {
+			return path, cl, nil
+		}
+
+		sub := cmd.command(args[0])
+		if sub == nil {
+			if len(cmd.Commands) == 0 {
+				return nil, nil, fmt.Errorf("%w for command '%s': %s", ErrUnexpectedArgument, commandPath(path), args[0])
+			}
+			return nil, nil, fmt.Errorf("%w for command '%s': %s", ErrUnknownCommand, commandPath(path), args[0])
+		}
+		path = append(path, sub)
+		args = args[1:]
+	}
+}
+
+// command returns the subcommand with the passed name or nil.
+func (c *Command) command(name string) *Command {
+	for _, sub := range c.Commands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// commandConfigs returns the configs of all commands of the path.
+func commandConfigs(path []*Command) []Config {
+	cfgs := make([]Config, 0, len(path))
+	for _, cmd := range path {
+		cfgs = append(cfgs, cmd.Configs...)
+	}
+	return cfgs
+}
+
+// commandPath returns the names of all commands of the path, e.g. app db migrate
+// An empty root command name is replaced with the name of the executable.
+func commandPath(path []*Command) string {
+	names := make([]string, 0, len(path))
+	for idx, cmd := range path {
+		name := cmd.Name
+		if idx == 0 && name == "" {
+			name = filepath.Base(os.Args[0])
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, " ")
+}
+
+// setCommandUsage replaces the usage of the flag set with the help text of the last command of the path.
+func setCommandUsage(flags *flag.FlagSet, path []*Command) {
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), commandUsage(path))
+	}
+}
+
+// commandUsage renders the help text of the last command of the path, which contains its description,
+// its subcommands and its flags.
+func commandUsage(path []*Command) string {
+	cmd := path[len(path)-1]
+
+	var sb strings.Builder
+	sb.WriteString("Usage: " + commandPath(path) + " [flags]")
+	if len(cmd.Commands) > 0 {
+		sb.WriteString(" <command>")
+	}
+	sb.WriteString("\n")
+
+	if cmd.Description != "" {
+		sb.WriteString("\n")
+		for _, line := range wrapText(cmd.Description, UsageWidth) {
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	if len(cmd.Commands) > 0 {
+		column := 0
+		for _, sub := range cmd.Commands {
+			if l := len(sub.Name) + 4; l > column && l <= usageMaxColumn {
+				column = l
+			}
+		}
+		if column == 0 {
+			column = usageMaxColumn
+		}
+
+		width := UsageWidth - column
+		if width < usageMinDescriptionWidth {
+			width = usageMinDescriptionWidth
+		}
+
+		sb.WriteString("\nCommands:\n")
+		for _, sub := range cmd.Commands {
+			left := "  " + sub.Name
+			lines := wrapText(sub.Description, width)
+			if len(left)+2 > column {
+				sb.WriteString(left + "\n")
+			} else {
+				sb.WriteString(strings.TrimRight(left+strings.Repeat(" ", column-len(left))+lines[0], " ") + "\n")
+				lines = lines[1:]
+			}
+			for _, line := range lines {
+				sb.WriteString(strings.Repeat(" ", column) + line + "\n")
+			}
+		}
+	}
+
+	writeUsageGroups(&sb, len(path) == 1, cmd.Configs...)
+	return sb.String()
+}
@@ -0,0 +1,162 @@
+package configo_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type globalCfg struct {
+	verbose bool
+}
+
+func (gc *globalCfg) Name() string {
+	return "Global"
+}
+
+func (gc *globalCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "VERBOSE",
+			Description:   "enable verbose logging",
+			DefaultValue:  "false",
+			ShortFlag:     "v",
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Bool(&gc.verbose),
+		},
+	}
+}
+
+type serveCfg struct {
+	port int
+}
+
+func (sc *serveCfg) Name() string {
+	return "Serve"
+}
+
+func (sc *serveCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "SERVE_PORT",
+			Description:   "listen port",
+			DefaultValue:  "8080",
+			ParseFunction: parsers.Int(&sc.port),
+		},
+	}
+}
+
+type migrateCfg struct {
+	steps int
+}
+
+func (mc *migrateCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "MIGRATE_STEPS",
+			Description:   "number of migration steps",
+			DefaultValue:  "1",
+			ParseFunction: parsers.Int(&mc.steps),
+		},
+	}
+}
+
+type commandTree struct {
+	global  globalCfg
+	serve   serveCfg
+	migrate migrateCfg
+	root    *configo.Command
+}
+
+func newCommandTree() *commandTree {
+	ct := &commandTree{}
+	ct.root = &configo.Command{
+		Name:    "app",
+		Configs: []configo.Config{&ct.global},
+		Commands: []*configo.Command{
+			{
+				Name:        "serve",
+				Description: "start the server",
+				Configs:     []configo.Config{&ct.serve},
+			},
+			{
+				Name:        "db",
+				Description: "database maintenance",
+				Commands: []*configo.Command{
+					{
+						Name:        "migrate",
+						Description: "migrate the database schema",
+						Configs:     []configo.Config{&ct.migrate},
+					},
+				},
+			},
+		},
+	}
+	return ct
+}
+
+func TestCommandParseFlags(t *testing.T) {
+	ct := newCommandTree()
+	cmd, err := ct.root.ParseFlags([]string{"-v", "serve", "--serve-port", "9090"})
+	require.NoError(t, err)
+	assert.Equal(t, "serve", cmd.Name)
+	assert.True(t, ct.global.verbose)
+	assert.Equal(t, 9090, ct.serve.port)
+	assert.Equal(t, 0, ct.migrate.steps, "options of other commands must not be parsed")
+
+	ct = newCommandTree()
+	cmd, err = ct.root.ParseFlags([]string{"db", "migrate", "--migrate-steps=3"})
+	require.NoError(t, err)
+	assert.Equal(t, "migrate", cmd.Name)
+	assert.False(t, ct.global.verbose)
+	assert.Equal(t, 3, ct.migrate.steps)
+
+	cmd, err = newCommandTree().root.ParseFlags([]string{})
+	require.NoError(t, err)
+	assert.Equal(t, "app", cmd.Name)
+}
+
+func TestCommandParseFlagsErrors(t *testing.T) {
+	_, err := newCommandTree().root.ParseFlags([]string{"backup"})
+	require.ErrorIs(t, err, configo.ErrUnknownCommand)
+
+	_, err = newCommandTree().root.ParseFlags([]string{"serve", "extra"})
+	require.ErrorIs(t, err, configo.ErrUnexpectedArgument)
+
+	// global options must be passed before the command name
+	_, err = newCommandTree().root.ParseFlags([]string{"serve", "--verbose"})
+	require.Error(t, err)
+}
+
+func TestCommandParseEnvFileOrEnvOrFlags(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("SERVE_PORT=1000\nVERBOSE=true\n"), 0600))
+	setenv(t, "SERVE_PORT", "2000")
+
+	ct := newCommandTree()
+	cmd, err := ct.root.ParseEnvFileOrEnvOrFlags(filePath, []string{"serve"})
+	require.NoError(t, err)
+	assert.Equal(t, "serve", cmd.Name)
+	assert.True(t, ct.global.verbose)
+	assert.Equal(t, 2000, ct.serve.port)
+
+	ct = newCommandTree()
+	_, err = ct.root.ParseEnvFileOrEnvOrFlags(filePath, []string{"--no-verbose", "serve", "--serve-port", "3000"})
+	require.NoError(t, err)
+	assert.False(t, ct.global.verbose)
+	assert.Equal(t, 3000, ct.serve.port)
+}
+
+func TestCommandHelp(t *testing.T) {
+	_, err := newCommandTree().root.ParseFlags([]string{"--help"})
+	require.ErrorIs(t, err, flag.ErrHelp)
+
+	_, err = newCommandTree().root.ParseFlags([]string{"db", "migrate", "-h"})
+	require.ErrorIs(t, err, flag.ErrHelp)
+}
@@ -0,0 +1,247 @@
+package configo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	completionFuncNameReplacer = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// completionFlag is a single flag name with the information that is needed in order to complete its value.
+type completionFlag struct {
+	name        string // without dashes
+	description string
+	kind        Kind // KindBool flags do not expect any value
+	choices     []string
+}
+
+// BashCompletion returns a bash completion script for the flags that are derived from the
+// options of the passed configs.
+// Choices are completed as values, options of kind KindFile and KindDirectory are completed as paths.
+func BashCompletion(program string, cfgs ...Config) (string, error) {
+	flags, err := completionFlags(cfgs...)
+	if err != nil {
+		return "", err
+	}
+	funcName := "_" + completionFuncNameReplacer.ReplaceAllString(program, "_") + "_completion"
+
+	var sb strings.Builder
+	sb.WriteString("# bash completion for " + program + "\n")
+	sb.WriteString(funcName + "() {\n")
+	sb.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	sb.WriteString("    # --flag=value is split into --flag, = and value\n")
+	sb.WriteString("    if [[ \"$cur\" == \"=\" ]]; then\n")
+	sb.WriteString("        cur=\"\"\n")
+	sb.WriteString("    elif [[ \"$prev\" == \"=\" ]]; then\n")
+	sb.WriteString("        prev=\"${COMP_WORDS[COMP_CWORD-2]}\"\n")
+	sb.WriteString("    fi\n\n")
+
+	sb.WriteString("    case \"$prev\" in\n")
+	names := make([]string, 0, len(flags))
+	for _, f := range flags {
+		names = append(names, flagPrefix(f.name)+f.name)
+		if f.kind == KindBool {
+			continue
+		}
+
+		sb.WriteString("        " + flagPrefix(f.name) + f.name + ")\n")
+		switch {
+		case len(f.choices) > 0:
+			// compgen -W splits choices that contain spaces, they are matched one by one instead
+			choices := make([]string, 0, len(f.choices))
+			for _, choice := range f.choices {
+				choices = append(choices, shellQuote(choice))
+			}
+			sb.WriteString("            local choice choices=(" + strings.Join(choices, " ") + ")\n")
+			sb.WriteString("            COMPREPLY=()\n")
+			sb.WriteString("            for choice in \"${choices[@]}\"; do\n")
+			sb.WriteString("                [[ \"$choice\" == \"$cur\"* ]] && COMPREPLY+=(\"$(printf '%q' \"$choice\")\")\n")
+			sb.WriteString("            done\n")
+		case f.kind == KindFile:
+			sb.WriteString("            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+		case f.kind == KindDirectory:
+			sb.WriteString("            COMPREPLY=($(compgen -d -- \"$cur\"))\n")
+		default:
+			sb.WriteString("            COMPREPLY=()\n")
+		}
+		sb.WriteString("            return 0\n")
+		sb.WriteString("            ;;\n")
+	}
+	sb.WriteString("    esac\n\n")
+
+	sb.WriteString("    if [[ \"$cur\" == -* ]]; then\n")
+	sb.WriteString("        COMPREPLY=($(compgen -W " + shellQuote(strings.Join(names, " ")) + " -- \"$cur\"))\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("}\n")
+	sb.WriteString("complete -o default -F " + funcName + " " + program + "\n")
+	return sb.String(), nil
+}
+
+// ZshCompletion returns a zsh completion script for the flags that are derived from the
+// options of the passed configs. The descriptions of the options are shown as flag descriptions.
+// Choices are completed as values, options of kind KindFile and KindDirectory are completed as paths.
+func ZshCompletion(program string, cfgs ...Config) (string, error) {
+	flags, err := completionFlags(cfgs...)
+	if err != nil {
+		return "", err
+	}
+	funcName := "_" + completionFuncNameReplacer.ReplaceAllString(program, "_")
+
+	var sb strings.Builder
+	sb.WriteString("#compdef " + program + "\n\n")
+	sb.WriteString(funcName + "() {\n")
+	sb.WriteString("    _arguments -s")
+	for _, f := range flags {
+		spec := flagPrefix(f.name) + f.name
+		if f.kind != KindBool {
+			if isShortFlag(f.name) {
+				spec += "+"
+			} else {
+				spec += "="
+			}
+		}
+		spec += "[" + zshEscape(f.description) + "]"
+
+		switch {
+		case f.kind == KindBool:
+		case len(f.choices) > 0:
+			choices := make([]string, 0, len(f.choices))
+			for _, choice := range f.choices {
+				choices = append(choices, zshEscapeValue(choice))
+			}
+			spec += ":" + f.name + ":(" + strings.Join(choices, " ") + ")"
+		case f.kind == KindFile:
+			spec += ":" + f.name + ":_files"
+		case f.kind == KindDirectory:
+			spec += ":" + f.name + ":_files -/"
+		default:
+			spec += ":" + f.name + ": "
+		}
+		sb.WriteString(" \\\n        " + shellQuote(spec))
+	}
+	sb.WriteString("\n}\n\n")
+	sb.WriteString("compdef " + funcName + " " + program + "\n")
+	return sb.String(), nil
+}
+
+// FishCompletion returns a fish completion script for the flags that are derived from the
+// options of the passed configs. The descriptions of the options are shown as flag descriptions.
+// Choices are completed as values, options of kind KindFile and KindDirectory are completed as paths.
+func FishCompletion(program string, cfgs ...Config) (string, error) {
+	flags, err := completionFlags(cfgs...)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# fish completion for " + program + "\n")
+	for _, f := range flags {
+		sb.WriteString("complete -c " + program)
+		if isShortFlag(f.name) {
+			sb.WriteString(" -s " + f.name)
+		} else {
+			sb.WriteString(" -l " + f.name)
+		}
+
+		switch {
+		case f.kind == KindBool:
+		case len(f.choices) > 0:
+			choices := make([]string, 0, len(f.choices))
+			for _, choice := range f.choices {
+				choices = append(choices, fishEscapeValue(choice))
+			}
+			sb.WriteString(" -x -a " + fishQuote(strings.Join(choices, " ")))
+		case f.kind == KindFile:
+			sb.WriteString(" -r -F")
+		case f.kind == KindDirectory:
+			sb.WriteString(" -x -a " + fishQuote("(__fish_complete_directories)"))
+		default:
+			sb.WriteString(" -x")
+		}
+		sb.WriteString(" -d " + fishQuote(f.description) + "\n")
+	}
+	return sb.String(), nil
+}
+
+// completionFlags returns all flag names of the options including short flags and negations.
+func completionFlags(cfgs ...Config) ([]completionFlag, error) {
+	options := flagOptions(cfgs...)
+	if err := checkFlagNames(options, true); err != nil {
+		return nil, err
+	}
+	options = append(options, globalFlagOptions()...)
+
+	result := make([]completionFlag, 0, len(options))
+	for _, opt := range options {
+		if !opt.allowsSource(SourceFlag) || !opt.visible() {
+			continue
+		}
+		flagName := opt.flagName()
+		f := completionFlag{
+			name:        flagName,
+			description: completionDescription(opt),
+			kind:        opt.Kind,
+			choices:     opt.Choices,
+		}
+		result = append(result, f)
+		if opt.ShortFlag != "" {
+			short := f
+			short.name = opt.ShortFlag
+			result = append(result, short)
+		}
+		if f.kind == KindBool {
+			result = append(result, completionFlag{
+				name:        "no-" + flagName,
+				description: "negates --" + flagName,
+				kind:        KindBool,
+			})
+		}
+	}
+	return result, nil
+}
+
+// completionDescription returns the first line of the option's description or its env key.
+func completionDescription(opt Option) string {
+	description := strings.TrimSpace(strings.SplitN(opt.Description, "\n", 2)[0])
+	if description == "" {
+		return fmt.Sprintf("env: %s", opt.Key)
+	}
+	return description
+}
+
+// flagPrefix returns - for short flags and -- for long flags.
+func flagPrefix(name string) string {
+	if isShortFlag(name) {
+		return "-"
+	}
+	return "--"
+}
+
+// isShortFlag returns true for single character flag names, e.g. v or ü
+func isShortFlag(name string) bool {
+	return utf8.RuneCountInString(name) == 1
+}
+
+// zshEscape escapes the description of an _arguments spec.
+func zshEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
+
+// zshEscapeValue escapes a single value of an _arguments value list.
+func zshEscapeValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ` `, `\ `, `(`, `\(`, `)`, `\)`, `:`, `\:`).Replace(s)
+}
+
+// fishEscapeValue escapes a single value of a fish argument list.
+func fishEscapeValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ` `, `\ `, `'`, `\'`, `"`, `\"`, `$`, `\$`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// fishQuote returns the single quoted fish string.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,117 @@
+package configo_test
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type completionCfg struct {
+	verbose bool
+	mode    string
+	file    string
+	dir     string
+	name    string
+}
+
+func (cc *completionCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "VERBOSE",
+			Description:   "enable verbose logging",
+			ShortFlag:     "v",
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Bool(&cc.verbose),
+		},
+		{
+			Key:           "MODE",
+			Description:   "mode [default]",
+			Kind:          configo.KindChoice,
+			Choices:       []string{"fast", "slow", "very slow"},
+			ParseFunction: parsers.ChoiceString(&cc.mode, "slow", "fast", "very slow"),
+		},
+		{
+			Key:           "CONFIG_FILE",
+			Description:   "config file",
+			Kind:          configo.KindFile,
+			ParseFunction: parsers.PathFile(&cc.file),
+		},
+		{
+			Key:           "DATA_DIR",
+			Description:   "data directory",
+			Kind:          configo.KindDirectory,
+			ParseFunction: parsers.PathDirectory(&cc.dir),
+		},
+		{
+			Key:           "NAME",
+			ParseFunction: parsers.String(&cc.name),
+		},
+	}
+}
+
+func TestBashCompletion(t *testing.T) {
+	script, err := configo.BashCompletion("my-app", &completionCfg{})
+	require.NoError(t, err)
+
+	assert.Contains(t, script, "_my_app_completion() {")
+	assert.Contains(t, script, "        --mode)\n            local choice choices=('fast' 'slow' 'very slow')\n")
+	assert.Contains(t, script, "        --config-file)\n            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	assert.Contains(t, script, "        --data-dir)\n            COMPREPLY=($(compgen -d -- \"$cur\"))\n")
+	assert.Contains(t, script, "compgen -W '--verbose -v --no-verbose --mode --config-file --data-dir --name'")
+	assert.Contains(t, script, "complete -o default -F _my_app_completion my-app\n")
+
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not installed")
+	}
+	file := filepath.Join(t.TempDir(), "completion.bash")
+	require.NoError(t, ioutil.WriteFile(file, []byte(script), 0600))
+	out, err := exec.Command(bash, "-c", `source "$1"
+COMP_WORDS=(my-app --mode f); COMP_CWORD=2; _my_app_completion; echo "${COMPREPLY[@]}"
+COMP_WORDS=(my-app --mode = s); COMP_CWORD=3; _my_app_completion; echo "${COMPREPLY[@]}"
+COMP_WORDS=(my-app --mode v); COMP_CWORD=2; _my_app_completion; echo "${COMPREPLY[@]}"
+COMP_WORDS=(my-app --no); COMP_CWORD=1; _my_app_completion; echo "${COMPREPLY[@]}"`, "bash", file).CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Equal(t, "fast\nslow\nvery\\ slow\n--no-verbose\n", string(out))
+}
+
+func TestZshCompletion(t *testing.T) {
+	script, err := configo.ZshCompletion("my-app", &completionCfg{})
+	require.NoError(t, err)
+
+	assert.Contains(t, script, "#compdef my-app\n")
+	assert.Contains(t, script, `'--verbose[enable verbose logging]'`)
+	assert.Contains(t, script, `'-v[enable verbose logging]'`)
+	assert.Contains(t, script, `'--mode=[mode \[default\]]:mode:(fast slow very\ slow)'`)
+	assert.Contains(t, script, `'--config-file=[config file]:config-file:_files'`)
+	assert.Contains(t, script, `'--data-dir=[data directory]:data-dir:_files -/'`)
+	assert.Contains(t, script, `'--name=[env: NAME]:name: '`)
+	assert.Contains(t, script, "compdef _my_app my-app\n")
+}
+
+func TestFishCompletion(t *testing.T) {
+	script, err := configo.FishCompletion("my-app", &completionCfg{})
+	require.NoError(t, err)
+
+	assert.Contains(t, script, "complete -c my-app -l verbose -d 'enable verbose logging'\n")
+	assert.Contains(t, script, "complete -c my-app -s v -d 'enable verbose logging'\n")
+	assert.Contains(t, script, "complete -c my-app -l no-verbose -d 'negates --verbose'\n")
+	assert.Contains(t, script, "complete -c my-app -l mode -x -a 'fast slow very\\\\ slow' -d 'mode [default]'\n")
+	assert.Contains(t, script, "complete -c my-app -l config-file -r -F -d 'config file'\n")
+	assert.Contains(t, script, "complete -c my-app -l data-dir -x -a '(__fish_complete_directories)' -d 'data directory'\n")
+	assert.Contains(t, script, "complete -c my-app -l name -x -d 'env: NAME'\n")
+}
+
+func TestCompletionCollision(t *testing.T) {
+	_, err := configo.BashCompletion("app", &completionCfg{}, &collisionCfg{configo.Options{
+		{Key: "MODE", Kind: configo.KindBool, ParseFunction: parsers.Bool(new(bool))},
+		{Key: "VERSION", ShortFlag: "v", Kind: configo.KindBool, ParseFunction: parsers.Bool(new(bool))},
+	}})
+	require.ErrorIs(t, err, configo.ErrFlagNameCollision)
+}
@@ -0,0 +1,84 @@
+package configo
+
+import (
+	"flag"
+	"strings"
+)
+
+var (
+	// ConfigFlag enables the built-in --config flag that selects the env files which are parsed as file layer.
+	// The flag may be passed multiple times, values of later files override the values of earlier files.
+	// In case that the flag is passed, the env file location that is passed to ParseEnvFileOrEnvOrFlags is ignored.
+	// ParseFlags and ParseEnvOrFlags use the files as lowest layer, too.
+	// For commands, the flag is a global flag of the root command.
+	ConfigFlag = false
+	// ConfigFlagName is the name of the built-in config flag, see ConfigFlag.
+	ConfigFlagName = "config"
+	// ConfigShortFlag is the shorthand of the built-in config flag, see ConfigFlag. May be empty.
+	ConfigShortFlag = "c"
+)
+
+// configFlagOption is the pseudo option that represents the built-in config flag in the help text.
+func configFlagOption() Option {
+	return Option{
+		Description: "path to an env file, may be passed multiple times",
+		Kind:        KindFile,
+		FlagName:    ConfigFlagName,
+		ShortFlag:   ConfigShortFlag,
+	}
+}
+
+// defineConfigFlag defines the built-in config flag.
+func defineConfigFlag(flags *flag.FlagSet) {
+	value := &configFlagValue{}
+	flags.Var(value, ConfigFlagName, configFlagOption().Description)
+	if ConfigShortFlag != "" {
+		flags.Var(value, ConfigShortFlag, "shorthand for --"+ConfigFlagName)
+	}
+}
+
+// configFiles returns the files that were passed with the built-in config flag.
+func configFiles(flags *flag.FlagSet) []string {
+	f := flags.Lookup(ConfigFlagName)
+	if f == nil {
+		return nil
+	}
+	value, ok := f.Value.(*configFlagValue)
+	if !ok {
+		return nil
+	}
+	return value.files
+}
+
+// readEnvFiles reads and merges the env files, values of later files override the values of earlier files.
+// Additionally to the values, the path of the file that supplied every value is returned.
+func readEnvFiles(files []string) (map[string]string, map[string]string, error) {
+	env := make(map[string]string)
+	provenance := make(map[string]string)
+	for _, filePath := range files {
+		fileMap, fileProvenance, err := readEnvFileProvenance(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		env = update(env, fileMap)
+		provenance = update(provenance, fileProvenance)
+	}
+	return env, provenance, nil
+}
+
+// configFlagValue collects the values of the repeated config flag.
+type configFlagValue struct {
+	files []string
+}
+
+func (cfv *configFlagValue) String() string {
+	if cfv == nil {
+		return ""
+	}
+	return strings.Join(cfv.files, ",")
+}
+
+func (cfv *configFlagValue) Set(value string) error {
+	cfv.files = append(cfv.files, value)
+	return nil
+}
@@ -0,0 +1,124 @@
+package configo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configFlagCfg struct {
+	host string
+	port int
+}
+
+func (cc *configFlagCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "CF_HOST",
+			Description:   "host",
+			DefaultValue:  "localhost",
+			ParseFunction: parsers.String(&cc.host),
+		},
+		{
+			Key:           "CF_PORT",
+			Description:   "port",
+			DefaultValue:  "80",
+			ParseFunction: parsers.Int(&cc.port),
+		},
+	}
+}
+
+func enableConfigFlag(t *testing.T) {
+	configo.ConfigFlag = true
+	t.Cleanup(func() {
+		configo.ConfigFlag = false
+	})
+}
+
+func TestConfigFlag(t *testing.T) {
+	enableConfigFlag(t)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"default.env": "CF_HOST=default\nCF_PORT=1000\n",
+		"first.env":   "CF_HOST=first\nCF_PORT=1001\n",
+		"second.env":  "CF_PORT=1002\n",
+	})
+	defaultFile := filepath.Join(dir, "default.env")
+	first := filepath.Join(dir, "first.env")
+	second := filepath.Join(dir, "second.env")
+
+	defer func(args []string) {
+		os.Args = args
+	}(os.Args)
+
+	os.Args = []string{"app"}
+	cfg := &configFlagCfg{}
+	require.NoError(t, configo.ParseEnvFileOrEnvOrFlags(defaultFile, cfg))
+	assert.Equal(t, "default", cfg.host)
+
+	os.Args = []string{"app", "--config", first, "-c=" + second}
+	cfg = &configFlagCfg{}
+	require.NoError(t, configo.ParseEnvFileOrEnvOrFlags(defaultFile, cfg))
+	assert.Equal(t, "first", cfg.host)
+	assert.Equal(t, 1002, cfg.port)
+
+	setenv(t, "CF_HOST", "env")
+	os.Args = []string{"app", "-c", first, "--cf-port", "2000"}
+	cfg = &configFlagCfg{}
+	require.NoError(t, configo.ParseEnvOrFlags(cfg))
+	assert.Equal(t, "env", cfg.host)
+	assert.Equal(t, 2000, cfg.port)
+
+	os.Args = []string{"app", "-c", filepath.Join(dir, "missing.env")}
+	require.Error(t, configo.ParseFlags(&configFlagCfg{}))
+}
+
+func TestConfigFlagDisabled(t *testing.T) {
+	_, err := configo.GetFlagMap([]string{"--config", "file.env"}, &configFlagCfg{})
+	require.Error(t, err)
+	assert.NotContains(t, configo.Usage("app", &configFlagCfg{}), "--config")
+}
+
+func TestConfigFlagUsage(t *testing.T) {
+	enableConfigFlag(t)
+	assert.Contains(t, configo.Usage("app", &configFlagCfg{}), `
+General:
+  -c, --config file    path to an env file, may be passed multiple times
+`)
+
+	script, err := configo.BashCompletion("app", &configFlagCfg{})
+	require.NoError(t, err)
+	assert.Contains(t, script, "        --config)\n            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+}
+
+func TestConfigFlagCollision(t *testing.T) {
+	enableConfigFlag(t)
+	_, err := configo.GetFlagMap([]string{}, &collisionCfg{configo.Options{
+		{Key: "CONFIG", ParseFunction: parsers.String(new(string))},
+	}})
+	require.ErrorIs(t, err, configo.ErrFlagNameCollision)
+}
+
+func TestConfigFlagCommand(t *testing.T) {
+	enableConfigFlag(t)
+	filePath := filepath.Join(t.TempDir(), "app.env")
+	writeFiles(t, filepath.Dir(filePath), map[string]string{
+		"app.env": "SERVE_PORT=1234\nVERBOSE=true\n",
+	})
+
+	ct := newCommandTree()
+	cmd, err := ct.root.ParseFlags([]string{"-c", filePath, "serve"})
+	require.NoError(t, err)
+	assert.Equal(t, "serve", cmd.Name)
+	assert.True(t, ct.global.verbose)
+	assert.Equal(t, 1234, ct.serve.port)
+
+	// the config flag is a global flag
+	_, err = newCommandTree().root.ParseFlags([]string{"serve", "-c", filePath})
+	require.Error(t, err)
+}
@@ -2,10 +2,11 @@ package configo
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 
-	"github.com/joho/godotenv"
+	"github.com/jxsl13/simple-configo/internal"
 )
 
 // Config is an interface that implements only two methods.
@@ -20,7 +21,11 @@ type Config interface {
 // ParseEnv parse the environment variables and fills all of the definied options on the
 // configuration.
 func ParseEnv(cfgs ...Config) error {
-	return Parse(GetEnv(), cfgs...)
+	env, err := mergeSources(nil, GetEnv(), nil, cfgs...)
+	if err != nil {
+		return err
+	}
+	return Parse(env, cfgs...)
 }
 
 // OptionDefaults returns a map of option keys and option default values
@@ -37,6 +42,19 @@ func OptionDefaults(cfgs ...Config) map[string]string {
 	return m
 }
 
+// SecretKeys returns the set of option keys that are marked as Secret.
+func SecretKeys(cfgs ...Config) map[string]bool {
+	m := make(map[string]bool)
+	for _, c := range cfgs {
+		for _, opt := range c.Options() {
+			if opt.IsOption() && opt.Secret {
+				m[opt.Key] = true
+			}
+		}
+	}
+	return m
+}
+
 func getFilePathOrKey(env map[string]string, filePathOrEnvKey string) string {
 	filePath := filePathOrEnvKey
 	value, found := env[filePathOrEnvKey]
@@ -53,7 +71,11 @@ func ParseEnvFile(filePathOrEnvKey string, cfgs ...Config) error {
 	env := GetEnv()
 	filePath := getFilePathOrKey(env, filePathOrEnvKey)
 
-	env, err := godotenv.Read(filePath)
+	fileMap, err := readEnvFile(filePath)
+	if err != nil {
+		return err
+	}
+	env, err = mergeSources(fileMap, nil, nil, cfgs...)
 	if err != nil {
 		return err
 	}
@@ -62,11 +84,18 @@ func ParseEnvFile(filePathOrEnvKey string, cfgs ...Config) error {
 
 // UnparseEnvFile is the opposite of ParseEnvFile. It serializes the map back into
 // the file.
+// Values of options that are marked as Secret are encrypted in case that an encryption key
+// is configured, see EncryptionKeyEnvKey.
+// Values of options that cannot be set via env files are skipped, see Option.Sources.
 func UnparseEnvFile(filePathOrEnvKey string, cfgs ...Config) error {
 	env, err := Unparse(cfgs...)
 	if err != nil {
 		return err
 	}
+	env, err = encryptSecrets(withoutSource(SourceFile, env, cfgs...), SecretKeys(cfgs...))
+	if err != nil {
+		return err
+	}
 	return UpdateEnvFile(env, filePathOrEnvKey)
 }
 
@@ -74,84 +103,199 @@ func UnparseEnvFile(filePathOrEnvKey string, cfgs ...Config) error {
 // parsing fails.
 // filePathOrEnvKey may be a file path or an environment key containing a file path
 // In case a variable is not found in theenv file the next level is tried which is the environment.
+// Only a missing env file is skipped, invalid or tampered env files result in an error.
 func ParseEnvFileOrEnv(filePathOrEnvKey string, cfgs ...Config) error {
 	env := GetEnv()
-	filePath := getFilePathOrKey(env, filePathOrEnvKey)
-	fileMap, err := godotenv.Read(filePath)
+	fileMap, _, err := readEnvFileLayer(env, filePathOrEnvKey, nil)
 	if err != nil {
-		// no file, parse env only
-		return Parse(env, cfgs...)
+		return err
 	}
 	// environment extends and overrides env file values
-	env = update(fileMap, env)
-
+	env, err = mergeSources(fileMap, env, nil, cfgs...)
+	if err != nil {
+		return err
+	}
 	return Parse(env, cfgs...)
 }
 
 // ParseFlags parses the flags provided to the application based on the
 // provided option definitions in every passed Config
+// The files of the built-in config flag are parsed as lowest layer, see ConfigFlag.
 func ParseFlags(cfgs ...Config) error {
+	_, err := parseFlags(os.Args[1:], cfgs...)
+	return err
+}
+
+// ParseFlagsArgs works like ParseFlags and additionally returns the positional arguments
+// that follow the flags, e.g. app --verbose file1 file2
+// The flag terminator '--' allows to pass positional arguments that start with a dash: app --verbose -- -file
+func ParseFlagsArgs(cfgs ...Config) ([]string, error) {
 	return parseFlags(os.Args[1:], cfgs...)
 }
 
 // allows to pass custom args for testing
-func parseFlags(args []string, cfgs ...Config) error {
-	flagMap, err := GetFlagMap(args, cfgs...)
+func parseFlags(args []string, cfgs ...Config) ([]string, error) {
+	cl, err := parseCommandLine(args, cfgs...)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return Parse(flagMap, cfgs...)
+	fileMap, provenance, err := readEnvFiles(cl.files)
+	if err != nil {
+		return nil, err
+	}
+	err = parseLayers(sourceLayers{
+		fileMap:        fileMap,
+		fileProvenance: provenance,
+		flagMap:        cl.flagMap,
+	}, cl.printConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return cl.positional, nil
 }
 
 // ParseEnvOrFlags fetches config values from the .env file, the environment
 // and from the flags and parses the configurations with those values provided as key value map.
+// The files of the built-in config flag are parsed as lowest layer, see ConfigFlag.
 func ParseEnvOrFlags(cfgs ...Config) error {
+	_, err := parseEnvOrFlags(os.Args[1:], cfgs...)
+	return err
+}
+
+// ParseEnvOrFlagsArgs works like ParseEnvOrFlags and additionally returns the positional arguments
+// that follow the flags, see ParseFlagsArgs.
+func ParseEnvOrFlagsArgs(cfgs ...Config) ([]string, error) {
 	return parseEnvOrFlags(os.Args[1:], cfgs...)
 }
 
 // parseEnvOrFlags allows passing of custom args for testing
-func parseEnvOrFlags(args []string, cfgs ...Config) error {
+func parseEnvOrFlags(args []string, cfgs ...Config) ([]string, error) {
+	cl, err := parseCommandLine(args, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	fileMap, provenance, err := readEnvFiles(cl.files)
+	if err != nil {
+		return nil, err
+	}
 	// override & extend env values with flag values
-	flagMap, err := GetFlagMap(args, cfgs...)
+	err = parseLayers(sourceLayers{
+		fileMap:        fileMap,
+		fileProvenance: provenance,
+		env:            GetEnv(),
+		flagMap:        cl.flagMap,
+	}, cl.printConfig, cfgs...)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	env := update(GetEnv(), flagMap)
-
-	// parse the combined map
-	return Parse(env, cfgs...)
+	return cl.positional, nil
 }
 
 // ParseEnvFileOrEnvOrFlags fetches config values from the .env file, the environment
 // and from the flags and parses the configurations with those values provided as key value map.
+// The files of the built-in config flag replace the env file, see ConfigFlag.
 // Warning: do not call this function multiple times with the same configurations, as redefiition of flag names
 // may cause a panic.
 func ParseEnvFileOrEnvOrFlags(filePathOrEnvKey string, cfgs ...Config) error {
+	_, err := parseEnvFileOrEnvOrFlags(filePathOrEnvKey, os.Args[1:], cfgs...)
+	return err
+}
+
+// ParseEnvFileOrEnvOrFlagsArgs works like ParseEnvFileOrEnvOrFlags and additionally returns the positional
+// arguments that follow the flags, see ParseFlagsArgs.
+func ParseEnvFileOrEnvOrFlagsArgs(filePathOrEnvKey string, cfgs ...Config) ([]string, error) {
 	return parseEnvFileOrEnvOrFlags(filePathOrEnvKey, os.Args[1:], cfgs...)
 }
 
 // parseEnvFileOrEnvOrFlags allows to pass custom os.Args[1:] for testing
-func parseEnvFileOrEnvOrFlags(filePathOrEnvKey string, args []string, cfgs ...Config) error {
+func parseEnvFileOrEnvOrFlags(filePathOrEnvKey string, args []string, cfgs ...Config) ([]string, error) {
 	// must always be parsed in order to fetch the potential file path
 	env := GetEnv()
-	flags, err := GetFlagMap(args, cfgs...)
+	cl, err := parseCommandLine(args, cfgs...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	filePath := getFilePathOrKey(env, filePathOrEnvKey)
-	fileMap, err := godotenv.Read(filePath)
+	fileMap, provenance, err := readEnvFileLayer(env, filePathOrEnvKey, cl.files)
 	if err != nil {
-		// parse environment variables extended and overwritten by flag parameters
-		return Parse(update(env, flags), cfgs...)
+		return nil, err
 	}
 
 	// override and update .env file with environment variables
 	// override and update .env file and environment variables with flag values
-	env = update(update(fileMap, env), flags)
+	err = parseLayers(sourceLayers{
+		fileMap:        fileMap,
+		fileProvenance: provenance,
+		env:            env,
+		flagMap:        cl.flagMap,
+	}, cl.printConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return cl.positional, nil
+}
 
-	// parse the combined map
-	return Parse(env, cfgs...)
+// commandLine contains the results of parsing the command line arguments.
+type commandLine struct {
+	flagMap map[string]string
+	// files that were passed with the built-in config flag
+	files []string
+	// format that was passed with the built-in print config flag
+	printConfig string
+	// remaining positional arguments
+	positional []string
+}
+
+// parseCommandLine parses the args and returns the flag values, the values of the built-in global flags
+// and the remaining positional arguments.
+func parseCommandLine(args []string, cfgs ...Config) (*commandLine, error) {
+	flags, values, err := newFlagSet("", flag.ContinueOnError, true, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	flagMap, err := parseFlagSet(flags, values, args)
+	if err != nil {
+		return nil, err
+	}
+	return &commandLine{
+		flagMap:     flagMap,
+		files:       configFiles(flags),
+		printConfig: printConfigFormat(flags),
+		positional:  flags.Args(),
+	}, nil
+}
+
+// readEnvFileLayer reads the files that were passed with the built-in config flag or, in case that no
+// files were passed, the env file at filePathOrEnvKey. A missing env file at filePathOrEnvKey is skipped.
+// Additionally to the values, the path of the file that supplied every value is returned.
+func readEnvFileLayer(env map[string]string, filePathOrEnvKey string, files []string) (map[string]string, map[string]string, error) {
+	if len(files) > 0 {
+		return readEnvFiles(files)
+	}
+
+	filePath := getFilePathOrKey(env, filePathOrEnvKey)
+	if !internal.Exists(filePath) {
+		return map[string]string{}, map[string]string{}, nil
+	}
+	return readEnvFileProvenance(filePath)
+}
+
+// parseLayers merges the source layers and parses them into the configs.
+// Afterwards the effective configuration is printed and ErrPrintConfig is returned in case that a print format
+// is passed, see PrintConfigFlag.
+func parseLayers(layers sourceLayers, printFormat string, cfgs ...Config) error {
+	env, err := mergeSources(layers.fileMap, layers.env, layers.flagMap, cfgs...)
+	if err != nil {
+		return err
+	}
+	err = Parse(env, cfgs...)
+	if err != nil {
+		return err
+	}
+	if printFormat != "" {
+		return printConfig(printFormat, layers, cfgs...)
+	}
+	return nil
 }
 
 // Parse the passed envoronment map into the config struct.
@@ -195,6 +339,21 @@ func Unparse(cfgs ...Config) (map[string]string, error) {
 	return resultMap, nil
 }
 
+// UnparseAll works like Unparse, but does not skip values that equal the default value of their option.
+func UnparseAll(cfgs ...Config) (map[string]string, error) {
+	resultMap := make(map[string]string)
+	for _, cfg := range cfgs {
+		env, err := unparseOptions(cfg.Options(), false)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range env {
+			resultMap[k] = v
+		}
+	}
+	return resultMap, nil
+}
+
 // UnparseValidate unparses the values and tries to parse the values again in order to validate their values
 // this allows to have a complex ParserFunction but a simple UnparserFunction, as all of the validation logic is
 // provided via the ParserFunction.
@@ -235,10 +394,14 @@ func UnparseValidate(cfgs ...Config) (map[string]string, error) {
 // as small as possible.
 // INFO: Not goroutine safe
 func UnparseOptions(options Options) (map[string]string, error) {
+	return unparseOptions(options, true)
+}
+
+func unparseOptions(options Options, skipDefaults bool) (map[string]string, error) {
 	env := make(map[string]string, len(options))
 	for _, opt := range options {
 
-		value, err := opt.Unparse()
+		value, err := opt.unparse(skipDefaults)
 		if err != nil {
 			if errors.Is(err, ErrSkipUnparse) {
 				continue
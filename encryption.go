@@ -0,0 +1,219 @@
+package configo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+const (
+	// EncryptedValuePrefix is the prefix of values that were encrypted with EncryptValue.
+	EncryptedValuePrefix = "enc:v1:"
+)
+
+var (
+	// EncryptionKeyEnvKey is the environment variable that contains the base64 encoded
+	// AES key (16, 24 or 32 bytes) that is used in order to encrypt and decrypt values.
+	EncryptionKeyEnvKey = "CONFIGO_ENCRYPTION_KEY"
+	// EncryptionKeyFileEnvKey is the environment variable that contains the path to a file
+	// which contains the base64 encoded AES key. It is used in case that the
+	// EncryptionKeyEnvKey environment variable is not set.
+	EncryptionKeyFileEnvKey = "CONFIGO_ENCRYPTION_KEY_FILE"
+
+	// ErrMissingEncryptionKey is returned when an encrypted value is found but no encryption key is configured.
+	ErrMissingEncryptionKey = errors.New("missing encryption key")
+	// ErrInvalidEncryptionKey is returned when the configured encryption key is not a base64 encoded
+	// 16, 24 or 32 byte long AES key.
+	ErrInvalidEncryptionKey = errors.New("invalid encryption key")
+	// ErrDecryptionFailed is returned when a value cannot be decrypted, e.g. due to a wrong key
+	// or a modified value.
+	ErrDecryptionFailed = errors.New("decryption failed")
+)
+
+// IsEncrypted returns true in case the value is prefixed with EncryptedValuePrefix.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, EncryptedValuePrefix)
+}
+
+// EncryptValue encrypts the value of the passed key with AES-GCM.
+// The key is used as additional authenticated data, which prevents
+// encrypted values from being moved to other keys.
+// The encryption key is taken from the environment, see EncryptionKeyEnvKey.
+// Example result: enc:v1:<base64>
+func EncryptValue(key, value string) (string, error) {
+	aead, err := newEncryptionAEAD()
+	if err != nil {
+		return "", err
+	}
+	return encryptValue(aead, key, value)
+}
+
+// DecryptValue decrypts the value of the passed key in case the value is encrypted.
+// Values that are not encrypted are returned as they are.
+// The encryption key is taken from the environment, see EncryptionKeyEnvKey.
+func DecryptValue(key, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	aead, err := newEncryptionAEAD()
+	if err != nil {
+		return "", err
+	}
+	return decryptValue(aead, key, value)
+}
+
+// EncryptEnvFile encrypts the values of the passed keys in the env file.
+// In case no keys are passed, all values are encrypted.
+// Included env files are not modified.
+// Values that are already encrypted are not encrypted a second time.
+func EncryptEnvFile(filePathOrEnvKey string, keys ...string) error {
+	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
+	aead, err := newEncryptionAEAD()
+	if err != nil {
+		return err
+	}
+	parts, err := loadEnvFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		for key, value := range part.env {
+			if (len(keys) > 0 && !internal.Contains(keys, key)) || IsEncrypted(value) {
+				continue
+			}
+			part.env[key], err = encryptValue(aead, key, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return writeEnvFile(parts, filePath)
+}
+
+// DecryptEnvFile decrypts all encrypted values in the env file.
+func DecryptEnvFile(filePathOrEnvKey string) error {
+	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
+	parts, err := loadEnvFile(filePath)
+	if err != nil {
+		return err
+	}
+	for i, part := range parts {
+		if part.include != nil {
+			continue
+		}
+		parts[i].env, err = decryptEnv(part.env)
+		if err != nil {
+			return err
+		}
+	}
+	return writeEnvFile(parts, filePath)
+}
+
+// decryptEnv returns a new map with all encrypted values decrypted.
+// The encryption key is only required in case that the map contains encrypted values.
+func decryptEnv(env map[string]string) (map[string]string, error) {
+	var aead cipher.AEAD
+	result := make(map[string]string, len(env))
+	for key, value := range env {
+		if !IsEncrypted(value) {
+			result[key] = value
+			continue
+		}
+		if aead == nil {
+			var err error
+			aead, err = newEncryptionAEAD()
+			if err != nil {
+				return nil, err
+			}
+		}
+		decrypted, err := decryptValue(aead, key, value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = decrypted
+	}
+	return result, nil
+}
+
+// encryptSecrets encrypts the values of all secret keys.
+// In case that no encryption key is configured, the map is returned as is.
+func encryptSecrets(env map[string]string, secrets map[string]bool) (map[string]string, error) {
+	aead, err := newEncryptionAEAD()
+	if errors.Is(err, ErrMissingEncryptionKey) {
+		return env, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for key, value := range env {
+		if !secrets[key] || IsEncrypted(value) {
+			continue
+		}
+		env[key], err = encryptValue(aead, key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return env, nil
+}
+
+func encryptValue(aead cipher.AEAD, key, value string) (string, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(key))
+	return EncryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(aead cipher.AEAD, key, value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("%w: key %s: %v", ErrDecryptionFailed, key, err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: key %s: value too short", ErrDecryptionFailed, key)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return "", fmt.Errorf("%w: key %s: %v", ErrDecryptionFailed, key, err)
+	}
+	return string(plaintext), nil
+}
+
+// newEncryptionAEAD looks up the encryption key in the environment and constructs an AES-GCM cipher.
+func newEncryptionAEAD() (cipher.AEAD, error) {
+	encodedKey := os.Getenv(EncryptionKeyEnvKey)
+	if encodedKey == "" {
+		keyFile := os.Getenv(EncryptionKeyFileEnvKey)
+		if keyFile == "" {
+			return nil, fmt.Errorf("%w: neither %s nor %s is set", ErrMissingEncryptionKey, EncryptionKeyEnvKey, EncryptionKeyFileEnvKey)
+		}
+		b, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMissingEncryptionKey, err)
+		}
+		encodedKey = string(b)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptionKey, err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptionKey, err)
+	}
+	return cipher.NewGCM(block)
+}
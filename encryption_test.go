@@ -0,0 +1,110 @@
+package configo_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/unparsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setenv sets the environment variable for the duration of the test
+func setenv(t *testing.T, key, value string) {
+	old, found := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if found {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func setEncryptionKey(t *testing.T) {
+	setenv(t, configo.EncryptionKeyEnvKey, base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+}
+
+func TestEncryptValue(t *testing.T) {
+	assert := assert.New(t)
+	setEncryptionKey(t)
+
+	encrypted, err := configo.EncryptValue("DB_PASSWORD", "s3cr3t")
+	assert.NoError(err)
+	assert.True(configo.IsEncrypted(encrypted))
+
+	decrypted, err := configo.DecryptValue("DB_PASSWORD", encrypted)
+	assert.NoError(err)
+	assert.Equal("s3cr3t", decrypted)
+
+	// encrypted values are bound to their key
+	_, err = configo.DecryptValue("OTHER_KEY", encrypted)
+	assert.True(errors.Is(err, configo.ErrDecryptionFailed))
+
+	plain, err := configo.DecryptValue("DB_HOST", "localhost")
+	assert.NoError(err)
+	assert.Equal("localhost", plain)
+
+	setenv(t, configo.EncryptionKeyEnvKey, "")
+	_, err = configo.DecryptValue("DB_PASSWORD", encrypted)
+	assert.True(errors.Is(err, configo.ErrMissingEncryptionKey))
+}
+
+func TestParseEncryptedValue(t *testing.T) {
+	setEncryptionKey(t)
+
+	encrypted, err := configo.EncryptValue("DB_PASSWORD", "s3cr3t")
+	require.NoError(t, err)
+
+	cfg := &kubernetesCfg{}
+	err = configo.Parse(map[string]string{"DB_PASSWORD": encrypted}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", cfg.password)
+}
+
+type unparseSecretCfg struct {
+	kubernetesCfg
+}
+
+func (uc *unparseSecretCfg) Options() configo.Options {
+	options := uc.kubernetesCfg.Options()
+	options[0].UnparseFunction = unparsers.String(&uc.host)
+	options[1].UnparseFunction = unparsers.String(&uc.password)
+	return options
+}
+
+func TestUnparseEnvFileEncrypted(t *testing.T) {
+	assert := assert.New(t)
+	setEncryptionKey(t)
+
+	filePath := filepath.Join(t.TempDir(), ".env")
+	cfg := &unparseSecretCfg{}
+	cfg.host = "db.example.com"
+	cfg.password = "changed"
+	require.NoError(t, configo.UnparseEnvFile(filePath, cfg))
+
+	env, err := configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.Equal("db.example.com", env["DB_HOST"])
+	assert.True(configo.IsEncrypted(env["DB_PASSWORD"]))
+
+	parsed := &unparseSecretCfg{}
+	require.NoError(t, configo.ParseEnvFile(filePath, parsed))
+	assert.Equal("changed", parsed.password)
+
+	require.NoError(t, configo.DecryptEnvFile(filePath))
+	env, err = configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.Equal("changed", env["DB_PASSWORD"])
+
+	require.NoError(t, configo.EncryptEnvFile(filePath))
+	env, err = configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.True(configo.IsEncrypted(env["DB_HOST"]))
+	assert.True(configo.IsEncrypted(env["DB_PASSWORD"]))
+}
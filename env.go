@@ -4,7 +4,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/joho/godotenv"
 	"github.com/jxsl13/simple-configo/internal"
 )
 
@@ -30,9 +29,10 @@ func SetEnv(env map[string]string) {
 
 // ReadEnvFile allows to read the env map from a key value file
 // File content: key=value
+// Included env files are resolved, see ReadEnvFileProvenance.
 func ReadEnvFile(filePathOrEnvKey string) (map[string]string, error) {
 	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
-	return godotenv.Read(filePath)
+	return readEnvFile(filePath)
 }
 
 // WriteEnvFile writes the map content into an env file
@@ -44,14 +44,20 @@ func WriteEnvFile(env map[string]string, filePathOrEnvKey string) error {
 	if err != nil {
 		return err
 	}
-	return godotenv.Write(env, filePath)
+	return writeEnvFile([]envFilePart{{env: env}}, filePath)
 }
 
 // UpdateEnvFile reads the file and update sits content to the new values.
+// The include directives of the file are kept, the values of included files are not copied into the file.
+// Existing keys are changed in place and new keys are appended, as the position of a key relative to
+// the include directives defines which value wins.
+// The file is signed after writing in case that a signature key is configured, see SignatureKeyEnvKey.
 func UpdateEnvFile(env map[string]string, filePathOrEnvKey string) error {
 	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
-	var err error
-	old := map[string]string{}
+	var (
+		err   error
+		parts []envFilePart
+	)
 
 	// try creating folder incase it's needed
 	err = internal.MkdirAll(filePath)
@@ -62,13 +68,40 @@ func UpdateEnvFile(env map[string]string, filePathOrEnvKey string) error {
 	// check if .env file exists
 	if internal.Exists(filePath) {
 		// update old values in case we can read the env file
-		old, err = godotenv.Read(filePath)
+		parts, err = loadEnvFile(filePath)
 		if err != nil {
 			return err
 		}
 	}
 	// update map and write back to filePath location
-	return godotenv.Write(update(old, env), filePath)
+	return writeEnvFile(updateEnvFileParts(parts, env), filePath)
+}
+
+// readEnvFile reads the env file, verifies its signature and resolves its includes.
+func readEnvFile(filePath string) (map[string]string, error) {
+	env, _, err := readEnvFileProvenance(filePath)
+	return env, err
+}
+
+// writeEnvFile writes the parts, include directives and blocks of key value pairs, into the env file
+// and signs it in case that a signature key is configured.
+// Unsigned files do not keep any sidecar signature file of their previous content.
+// The file is replaced atomically.
+func writeEnvFile(parts []envFilePart, filePath string) error {
+	content, err := marshalEnvFile(parts)
+	if err != nil {
+		return err
+	}
+
+	key := os.Getenv(SignatureKeyEnvKey)
+	if key == "" {
+		err = internal.WriteFileAtomic(filePath, []byte(content+"\n"), 0666)
+		if err != nil {
+			return err
+		}
+		return removeSignatureSidecar(filePath)
+	}
+	return writeSigned(filePath, content, key)
 }
 
 func update(old, new map[string]string) map[string]string {
@@ -0,0 +1,148 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+var (
+	// ErrInvalidFormatKey is returned when a key cannot be used as variable name in the target format.
+	ErrInvalidFormatKey = errors.New("key cannot be represented in the target format")
+	// ErrInvalidFormatValue is returned when a value cannot be represented in the target format,
+	// e.g. a value that contains a newline character in a docker env file.
+	ErrInvalidFormatValue = errors.New("value cannot be represented in the target format")
+
+	// POSIX shell and systemd variable names
+	shellKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// MarshalShell serializes the env map into a POSIX shell script that consists of
+// export KEY='value' lines. The lines are sorted by their keys.
+// Every value is single quoted, single quotes within values are closed, escaped and reopened.
+// Values may contain newlines but no NUL characters.
+func MarshalShell(env map[string]string) (string, error) {
+	var sb strings.Builder
+	for _, key := range sortedKeys(env) {
+		value := env[key]
+		if !shellKeyRegex.MatchString(key) {
+			return "", fmt.Errorf("%w: shell: %q", ErrInvalidFormatKey, key)
+		}
+		if strings.ContainsRune(value, 0) {
+			return "", fmt.Errorf("%w: shell: value of key %s contains a NUL character", ErrInvalidFormatValue, key)
+		}
+		sb.WriteString("export ")
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(shellQuote(value))
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// MarshalDockerEnv serializes the env map into the format that is expected by
+// docker run --env-file. The format does not support any quoting, which is why
+// values are written as they are. Values that contain newlines, carriage returns or NUL
+// characters cannot be represented and result in an error.
+func MarshalDockerEnv(env map[string]string) (string, error) {
+	var sb strings.Builder
+	for _, key := range sortedKeys(env) {
+		value := env[key]
+		if key == "" ||
+			strings.HasPrefix(key, "#") ||
+			strings.ContainsAny(key, "= \t\r\n\x00") {
+			return "", fmt.Errorf("%w: docker: %q", ErrInvalidFormatKey, key)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return "", fmt.Errorf("%w: docker: value of key %s contains a newline or NUL character", ErrInvalidFormatValue, key)
+		}
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(value)
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// MarshalSystemdEnv serializes the env map into the format that is expected by
+// the systemd EnvironmentFile= directive. Values are double quoted and the characters
+// \ " ` and $ are escaped with a backslash. Values that contain newlines, carriage returns
+// or NUL characters cannot be represented and result in an error.
+func MarshalSystemdEnv(env map[string]string) (string, error) {
+	var sb strings.Builder
+	for _, key := range sortedKeys(env) {
+		value := env[key]
+		if !shellKeyRegex.MatchString(key) {
+			return "", fmt.Errorf("%w: systemd: %q", ErrInvalidFormatKey, key)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return "", fmt.Errorf("%w: systemd: value of key %s contains a newline or NUL character", ErrInvalidFormatValue, key)
+		}
+		sb.WriteString(key)
+		sb.WriteString("=\"")
+		for _, r := range value {
+			switch r {
+			case '\\', '"', '`', '$':
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+		}
+		sb.WriteString("\"\n")
+	}
+	return sb.String(), nil
+}
+
+// WriteShellFile writes the env map as POSIX shell script of export statements.
+// The resulting file can be sourced by a shell.
+func WriteShellFile(env map[string]string, filePathOrEnvKey string) error {
+	return writeFormattedFile(env, filePathOrEnvKey, MarshalShell)
+}
+
+// WriteDockerEnvFile writes the env map into a file that can be passed to docker run --env-file.
+func WriteDockerEnvFile(env map[string]string, filePathOrEnvKey string) error {
+	return writeFormattedFile(env, filePathOrEnvKey, MarshalDockerEnv)
+}
+
+// WriteSystemdEnvFile writes the env map into a file that can be referenced by
+// the systemd EnvironmentFile= directive.
+func WriteSystemdEnvFile(env map[string]string, filePathOrEnvKey string) error {
+	return writeFormattedFile(env, filePathOrEnvKey, MarshalSystemdEnv)
+}
+
+func writeFormattedFile(env map[string]string, filePathOrEnvKey string, marshal func(map[string]string) (string, error)) error {
+	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
+
+	// marshal first in order not to create any directories for invalid content
+	content, err := marshal(env)
+	if err != nil {
+		return err
+	}
+
+	// try creating folder incase it's needed
+	err = internal.MkdirAll(filePath)
+	if err != nil {
+		return err
+	}
+	// the files may contain credentials
+	return ioutil.WriteFile(filePath, []byte(content), 0600)
+}
+
+// shellQuote wraps the value in single quotes. Single quotes within the value
+// are closed, escaped and reopened.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func sortedKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
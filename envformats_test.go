@@ -0,0 +1,81 @@
+package configo_test
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalShell(t *testing.T) {
+	assert := assert.New(t)
+
+	out, err := configo.MarshalShell(map[string]string{
+		"B_KEY": "it's",
+		"A_KEY": "multi\nline $HOME",
+	})
+	assert.NoError(err)
+	assert.Equal("export A_KEY='multi\nline $HOME'\nexport B_KEY='it'\\''s'\n", out)
+
+	_, err = configo.MarshalShell(map[string]string{"INVALID-KEY": "value"})
+	assert.True(errors.Is(err, configo.ErrInvalidFormatKey))
+
+	_, err = configo.MarshalShell(map[string]string{"KEY": "a\x00b"})
+	assert.True(errors.Is(err, configo.ErrInvalidFormatValue))
+}
+
+func TestMarshalShellSourced(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no shell available")
+	}
+	env := map[string]string{
+		"QUOTED": `it's "quoted" \ $HOME ` + "`ls`",
+	}
+	filePath := filepath.Join(t.TempDir(), "env.sh")
+	require.NoError(t, configo.WriteShellFile(env, filePath))
+
+	out, err := exec.Command(sh, "-c", `. "$0" && printf '%s' "$QUOTED"`, filePath).Output()
+	require.NoError(t, err)
+	assert.Equal(t, env["QUOTED"], string(out))
+}
+
+func TestMarshalDockerEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	out, err := configo.MarshalDockerEnv(map[string]string{
+		"KEY":   `"no quoting" 'at all'`,
+		"EMPTY": "",
+	})
+	assert.NoError(err)
+	assert.Equal("EMPTY=\nKEY=\"no quoting\" 'at all'\n", out)
+
+	_, err = configo.MarshalDockerEnv(map[string]string{"KEY": "multi\nline"})
+	assert.True(errors.Is(err, configo.ErrInvalidFormatValue))
+
+	_, err = configo.MarshalDockerEnv(map[string]string{"KEY WITH SPACE": "value"})
+	assert.True(errors.Is(err, configo.ErrInvalidFormatKey))
+}
+
+func TestMarshalSystemdEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	out, err := configo.MarshalSystemdEnv(map[string]string{
+		"KEY": `a "b" \c $d` + "`e`",
+	})
+	assert.NoError(err)
+	assert.Equal("KEY=\"a \\\"b\\\" \\\\c \\$d\\`e\\`\"\n", out)
+
+	_, err = configo.MarshalSystemdEnv(map[string]string{"KEY": "multi\r\nline"})
+	assert.True(errors.Is(err, configo.ErrInvalidFormatValue))
+
+	// invalid values must not create any files
+	filePath := filepath.Join(t.TempDir(), "sub", "env")
+	err = configo.WriteSystemdEnvFile(map[string]string{"KEY": "\n"}, filePath)
+	assert.Error(err)
+	assert.NoFileExists(filePath)
+}
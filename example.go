@@ -0,0 +1,94 @@
+package configo
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+// EnvExample returns the content of an example env file, e.g. .env.example, that documents every
+// option which can be set via env files. Every key is preceded by comments that contain its description,
+// whether it is required and its allowed choices. The values are the default values of the options.
+// Secret options are commented out without any value, as an empty value would override their default value.
+// Hidden options are skipped unless IncludeHiddenOptions is set.
+func EnvExample(cfgs ...Config) (string, error) {
+	var sb strings.Builder
+	seen := make(map[string]bool)
+	for _, cfg := range cfgs {
+		title := ""
+		if named, ok := cfg.(interface{ Name() string }); ok {
+			title = named.Name()
+		}
+
+		for _, opt := range cfg.Options() {
+			if !opt.IsOption() || seen[opt.Key] || !opt.visible() || !opt.allowsSource(SourceFile) {
+				continue
+			}
+			seen[opt.Key] = true
+
+			if title != "" {
+				// title of the first option of the config
+				if sb.Len() > 0 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString("### " + title + "\n")
+				title = ""
+			}
+			if err := writeEnvExampleOption(&sb, opt); err != nil {
+				return "", err
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// WriteEnvExampleFile writes the result of EnvExample into the file.
+func WriteEnvExampleFile(filePathOrEnvKey string, cfgs ...Config) error {
+	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
+	content, err := EnvExample(cfgs...)
+	if err != nil {
+		return err
+	}
+
+	// try creating folder incase it's needed
+	err = internal.MkdirAll(filePath)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, []byte(content), 0666)
+}
+
+func writeEnvExampleOption(sb *strings.Builder, opt Option) error {
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	if strings.TrimSpace(opt.Description) != "" {
+		for _, line := range wrapText(opt.Description, UsageWidth-2) {
+			sb.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+		}
+	}
+
+	details := make([]string, 0, 2)
+	if opt.Mandatory && opt.DefaultValue == "" {
+		details = append(details, "required")
+	}
+	if len(opt.Choices) > 0 {
+		details = append(details, "choices: "+strings.Join(opt.Choices, ", "))
+	}
+	if len(details) > 0 {
+		sb.WriteString("# (" + strings.Join(details, ", ") + ")\n")
+	}
+
+	if opt.Secret {
+		sb.WriteString("# " + opt.Key + "=\n")
+		return nil
+	}
+	line, err := godotenv.Marshal(map[string]string{opt.Key: opt.DefaultValue})
+	if err != nil {
+		return err
+	}
+	sb.WriteString(line + "\n")
+	return nil
+}
@@ -0,0 +1,123 @@
+package configo_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hiddenCfg struct {
+	host     string
+	password string
+	mode     string
+	trace    bool
+	testHook string
+}
+
+func (hc *hiddenCfg) Name() string {
+	return "Database"
+}
+
+func (hc *hiddenCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "DB_HOST",
+			Description:   "host name of the database",
+			Mandatory:     true,
+			ParseFunction: parsers.String(&hc.host),
+		},
+		{
+			Key:           "DB_PASSWORD",
+			Description:   "password of the database user",
+			DefaultValue:  "changeme",
+			Secret:        true,
+			ParseFunction: parsers.String(&hc.password),
+		},
+		{
+			Key:           "DB_MODE",
+			DefaultValue:  "rw",
+			Kind:          configo.KindChoice,
+			Choices:       []string{"ro", "rw"},
+			ParseFunction: parsers.ChoiceString(&hc.mode, "rw", "ro"),
+		},
+		{
+			Key:           "DB_TRACE",
+			Description:   "trace all queries",
+			DefaultValue:  "false",
+			Sources:       configo.SourceFlag,
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Bool(&hc.trace),
+		},
+		{
+			Key:           "DB_TEST_HOOK",
+			Description:   "only used in tests",
+			Hidden:        true,
+			ParseFunction: parsers.String(&hc.testHook),
+		},
+	}
+}
+
+func includeHiddenOptions(t *testing.T) {
+	configo.IncludeHiddenOptions = true
+	t.Cleanup(func() {
+		configo.IncludeHiddenOptions = false
+	})
+}
+
+func TestEnvExample(t *testing.T) {
+	want := `### Database
+
+# host name of the database
+# (required)
+DB_HOST=""
+
+# password of the database user
+# DB_PASSWORD=
+
+# (choices: ro, rw)
+DB_MODE="rw"
+`
+	got, err := configo.EnvExample(&hiddenCfg{})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	filePath := filepath.Join(t.TempDir(), ".env.example")
+	require.NoError(t, configo.WriteEnvExampleFile(filePath, &hiddenCfg{}))
+	env, err := configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"DB_HOST": "", "DB_MODE": "rw"}, env)
+
+	includeHiddenOptions(t)
+	got, err = configo.EnvExample(&hiddenCfg{})
+	require.NoError(t, err)
+	assert.Contains(t, got, "\n# only used in tests\nDB_TEST_HOOK=\"\"\n")
+}
+
+func TestHiddenOptions(t *testing.T) {
+	cfg := &hiddenCfg{}
+	flagMap, err := configo.GetFlagMap([]string{"--db-host", "host", "--db-test-hook", "hook"}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, configo.Parse(flagMap, cfg))
+	assert.Equal(t, "hook", cfg.testHook)
+
+	assert.NotContains(t, configo.Usage("app", cfg), "db-test-hook")
+	for _, completion := range []func(string, ...configo.Config) (string, error){
+		configo.BashCompletion,
+		configo.ZshCompletion,
+		configo.FishCompletion,
+	} {
+		script, err := completion("app", cfg)
+		require.NoError(t, err)
+		assert.NotContains(t, script, "db-test-hook")
+	}
+
+	includeHiddenOptions(t)
+	assert.Contains(t, configo.Usage("app", cfg), "--db-test-hook value")
+	script, err := configo.BashCompletion("app", cfg)
+	require.NoError(t, err)
+	assert.Contains(t, script, "--db-test-hook")
+}
@@ -1,10 +1,17 @@
 package configo
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/jxsl13/simple-configo/internal"
 )
 
 var (
@@ -12,8 +19,23 @@ var (
 	// cli flag name. e.g. FLAG_NAME to --flag-name for any Option that is NOT an .IsAction()
 	KeyToFlagNameTransformer = DefaultKeyToFlagNameTransformer
 
+	// arguments that only consist of these characters do not need to be quoted in a shell
+	shellSafeArg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
 	trimAndSpecialTransformer = regexp.MustCompile(`^-+|-+$|,|\.+|;+|:+`)
 	flagNameTransformer       = regexp.MustCompile(`\s+|_+`)
+
+	// ErrFlagNameCollision is returned when multiple options are represented by the same flag name.
+	ErrFlagNameCollision = errors.New("flag name collision")
+	// ErrInvalidFlagName is returned when the flag name of an option is empty, starts with a dash or contains a '='
+	// or when its short flag is not a single character other than '-' and '='.
+	ErrInvalidFlagName = errors.New("invalid flag name")
+
+	// flags that are defined by the flag package
+	builtinFlagNames = map[string]string{
+		"h":    "built-in help flag",
+		"help": "built-in help flag",
+	}
 )
 
 func DefaultKeyToFlagNameTransformer(key string) string {
@@ -36,83 +58,427 @@ func GetFlags(cfgs ...Config) map[string]string {
 
 // GetFlagMap returns a map of flags that consists of flag values passed via osArgs that can be found in
 // the cfg Options' keys.
+// The values of the built-in global flags are not part of the map, see ConfigFlag and PrintConfigFlag.
+// An error is returned in case that the flag names of the options collide, see ErrFlagNameCollision.
 func GetFlagMap(osArgs []string, cfgs ...Config) (map[string]string, error) {
 	return getFlagMapWithErrorHandling(osArgs, flag.ContinueOnError, cfgs...)
 }
 
+// UnparseFlags is the reverse operation of GetFlagMap. It unparses the configs and returns
+// a --flag=value argument for every option value, e.g. for spawning child processes:
+//
+//	args, err := configo.UnparseFlags(cfgs...)
+//	cmd := exec.Command(os.Args[0], args...)
+//
+// Like with Unparse, options that return ErrSkipUnparse or that do not differ from their default value are skipped.
+// Empty values are skipped as well, because empty flag values are ignored by GetFlagMap.
+// Options that do not allow the SourceFlag are skipped, see Option.Sources.
+// The arguments are passed as they are, use ShellJoin in order to construct a shell command line.
+func UnparseFlags(cfgs ...Config) ([]string, error) {
+	options := flagOptions(cfgs...)
+	if err := checkFlagNames(options, false); err != nil {
+		return nil, err
+	}
+	env, err := Unparse(cfgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, len(env))
+	for _, opt := range options {
+		value, found := env[opt.Key]
+		if !found || value == "" || !opt.allowsSource(SourceFlag) {
+			continue
+		}
+		args = append(args, "--"+opt.flagName()+"="+value)
+	}
+	return args, nil
+}
+
+// ShellJoin joins the arguments to a command line that can be passed to a POSIX shell.
+// Arguments that contain characters with special meaning are single quoted.
+func ShellJoin(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if shellSafeArg.MatchString(arg) {
+			quoted = append(quoted, arg)
+			continue
+		}
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
 // GetFlagSet constructs a flag.FlagSet from your configs that may be registered with cli tools for auto completion purposes.
 // e.g. Cobra/Viper
 // you may iterate ove rthe flagset with .Visit//.VisitAll
 // The main purpose of this is to define auto completion references.
+// Completion scripts can be generated with BashCompletion, ZshCompletion and FishCompletion.
+// The usage of the flag set prints the help text that is rendered by Usage.
+// Invalid or colliding flag names are handled with the errHandling. Like the flag package does for redefined flags,
+// GetFlagSet panics in case of flag.ContinueOnError, see CheckFlags.
 func GetFlagSet(setName string, errHandling flag.ErrorHandling, cfgs ...Config) *flag.FlagSet {
-	options := Options{}
+	flags, _, err := newFlagSet(setName, errHandling, true, cfgs...)
+	if err != nil {
+		panic(err)
+	}
+	return flags
+}
+
+// CheckFlags returns an error in case that the flag names of the options of the configs are invalid
+// or collide, see ErrInvalidFlagName and ErrFlagNameCollision.
+func CheckFlags(cfgs ...Config) error {
+	return checkFlagNames(flagOptions(cfgs...), true)
+}
+
+// getFlagMapWithErrorHandling parses the provided args according to your configo definitions.
+// -h and --help print the help text that is rendered by Usage and return flag.ErrHelp.
+func getFlagMapWithErrorHandling(osArgs []string, errHandling flag.ErrorHandling, cfgs ...Config) (map[string]string, error) {
+	flags, values, err := newFlagSet("", errHandling, true, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return parseFlagSet(flags, values, osArgs)
+}
+
+// newFlagSet checks the flag names of the options and defines them in a new flag set.
+// globals defines the enabled built-in global flags, see ConfigFlag and PrintConfigFlag.
+func newFlagSet(setName string, errHandling flag.ErrorHandling, globals bool, cfgs ...Config) (*flag.FlagSet, map[string]*flagValue, error) {
+	options := flagOptions(cfgs...)
+	if err := checkFlagNames(options, globals); err != nil {
+		return nil, nil, handleFlagError(errHandling, err)
+	}
+
+	flags := flag.NewFlagSet(setName, errHandling)
+	values := defineFlags(flags, options)
+	if globals {
+		for _, gf := range globalFlags() {
+			gf.define(flags)
+		}
+	}
+	setUsage(flags, setName, globals, cfgs...)
+	return flags, values, nil
+}
+
+// globalFlag is a built-in flag that is only defined for the root flag set.
+type globalFlag struct {
+	owner  string
+	option Option
+	define func(flags *flag.FlagSet)
+}
+
+// globalFlags returns the enabled built-in global flags, see ConfigFlag and PrintConfigFlag.
+func globalFlags() []globalFlag {
+	result := make([]globalFlag, 0, 2)
+	if ConfigFlag {
+		result = append(result, globalFlag{"built-in config flag", configFlagOption(), defineConfigFlag})
+	}
+	if PrintConfigFlag {
+		result = append(result, globalFlag{"built-in print config flag", printConfigFlagOption(), definePrintConfigFlag})
+	}
+	return result
+}
+
+// globalFlagOptions returns the pseudo options of the enabled built-in global flags.
+func globalFlagOptions() []Option {
+	gfs := globalFlags()
+	options := make([]Option, 0, len(gfs))
+	for _, gf := range gfs {
+		options = append(options, gf.option)
+	}
+	return options
+}
+
+// parseFlagSet parses the args and returns a map with the values of all flags that were set.
+// The remaining arguments can be retrieved with flags.Args()
+func parseFlagSet(flags *flag.FlagSet, values map[string]*flagValue, args []string) (map[string]string, error) {
+	err := flags.Parse(expandShortFlags(flags, args))
+	if err != nil {
+		for _, v := range values {
+			if v.err != nil {
+				return nil, v.err
+			}
+		}
+		return nil, err
+	}
+
+	env := make(map[string]string, len(values))
+	for k, v := range values {
+		if v.value != "" {
+			env[k] = v.value
+		}
+	}
+	return env, nil
+}
+
+// flagOptions returns a single option per key for all options that are not actions.
+// In case that multiple options share the same key, the last non-empty description is used.
+func flagOptions(cfgs ...Config) []Option {
+	options := make([]Option, 0, len(cfgs)*2)
+	indexes := make(map[string]int, len(cfgs)*2)
 	for _, cfg := range cfgs {
-		options = append(options, cfg.Options()...)
+		for _, opt := range cfg.Options() {
+			if opt.IsAction() {
+				// skip actions that do not have value parsing logic
+				continue
+			}
+
+			idx, found := indexes[opt.Key]
+			if !found {
+				indexes[opt.Key] = len(options)
+				options = append(options, opt)
+				continue
+			}
+			if opt.Description != "" {
+				options[idx].Description = opt.Description
+			}
+		}
 	}
+	return options
+}
 
-	// single key -> last description of that key
-	flagDefinitions := make(map[string]string, len(options))
+// checkFlagNames returns an error that lists all flag names which are used by more than one option
+// or by an option and a built-in flag. This includes short flags and the negations of boolean flags.
+// globals reserves the names of the enabled built-in global flags.
+func checkFlagNames(options []Option, globals bool) error {
+	owners := make(map[string][]string, len(options)+len(builtinFlagNames))
+	for name, owner := range builtinFlagNames {
+		owners[name] = append(owners[name], owner)
+	}
+	if globals {
+		for _, gf := range globalFlags() {
+			owners[gf.option.FlagName] = append(owners[gf.option.FlagName], gf.owner)
+			if gf.option.ShortFlag != "" {
+				owners[gf.option.ShortFlag] = append(owners[gf.option.ShortFlag], gf.owner)
+			}
+		}
+	}
 
+	invalid := make([]string, 0)
 	for _, opt := range options {
-		if opt.IsAction() {
-			// skip actions that do not have value parsing logic
+		flagName := opt.flagName()
+		if flagName == "" || strings.HasPrefix(flagName, "-") || strings.Contains(flagName, "=") {
+			invalid = append(invalid, fmt.Sprintf("'%s' of key %s", flagName, opt.Key))
+			continue
+		}
+		if sf := opt.ShortFlag; sf != "" && (utf8.RuneCountInString(sf) != 1 || sf == "-" || sf == "=") {
+			invalid = append(invalid, fmt.Sprintf("short flag '%s' of key %s", sf, opt.Key))
 			continue
 		}
+		owners[flagName] = append(owners[flagName], opt.Key)
+		if opt.ShortFlag != "" {
+			owners[opt.ShortFlag] = append(owners[opt.ShortFlag], opt.Key+" (short flag)")
+		}
+		if opt.Kind == KindBool {
+			owners["no-"+flagName] = append(owners["no-"+flagName], opt.Key+" (negation)")
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidFlagName, strings.Join(invalid, ", "))
+	}
 
-		_, found := flagDefinitions[opt.Key]
-		if !found || (found && opt.Description != "") {
-			flagDefinitions[opt.Key] = opt.Description
+	collisions := make([]string, 0)
+	for name, keys := range owners {
+		if len(keys) > 1 {
+			sort.Strings(keys)
+			collisions = append(collisions, fmt.Sprintf("%s%s: %s", flagPrefix(name), name, strings.Join(keys, ", ")))
 		}
 	}
+	if len(collisions) == 0 {
+		return nil
+	}
+	sort.Strings(collisions)
+	return fmt.Errorf("%w: %s", ErrFlagNameCollision, strings.Join(collisions, "; "))
+}
 
-	flags := flag.NewFlagSet(setName, errHandling)
-	for key, description := range flagDefinitions {
-		flagName := KeyToFlagNameTransformer(key)
-		// ignore the result pointer
-		flags.String(flagName, "", description)
+// handleFlagError handles errors that occur before the flags are parsed
+// the same way as the flag package handles parsing errors.
+func handleFlagError(errHandling flag.ErrorHandling, err error) error {
+	switch errHandling {
+	case flag.ExitOnError:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	case flag.PanicOnError:
+		panic(err)
 	}
-	return flags
+	return err
 }
 
-// getFlagMapWithErrorHandling parses the provided args according to your configo definitions.
-func getFlagMapWithErrorHandling(osArgs []string, errHandling flag.ErrorHandling, cfgs ...Config) (map[string]string, error) {
-	options := Options{}
-	for _, cfg := range cfgs {
-		options = append(options, cfg.Options()...)
+// defineFlags defines a flag for every option and returns the values of the flags by option key.
+// Options of kind KindBool are defined as boolean flags that do not need any value
+// and can be negated with the 'no-' prefix.
+// Options of kind KindList and KindMap are defined as flags that can be passed multiple times.
+func defineFlags(flags *flag.FlagSet, options []Option) map[string]*flagValue {
+	values := make(map[string]*flagValue, len(options))
+	for _, opt := range options {
+		flagName := opt.flagName()
+		value := &flagValue{
+			key:               opt.Key,
+			kind:              opt.Kind,
+			delimiter:         opt.Delimiter,
+			keyValueDelimiter: opt.KeyValueDelimiter,
+			notAllowed:        !opt.allowsSource(SourceFlag),
+		}
+		values[opt.Key] = value
+		flags.Var(value, flagName, opt.Description)
+		if opt.ShortFlag != "" {
+			flags.Var(value, opt.ShortFlag, "shorthand for --"+flagName)
+		}
+
+		if value.IsBoolFlag() {
+			flags.Var(&negatedFlagValue{value}, "no-"+flagName, "negates --"+flagName)
+		}
+	}
+	return values
+}
+
+// flagName returns the explicitly defined FlagName or the name that is derived from the option's Key.
+func (o *Option) flagName() string {
+	if o.FlagName != "" {
+		return o.FlagName
 	}
+	return KeyToFlagNameTransformer(o.Key)
+}
+
+// expandShortFlags splits combined short flags into separate flags, e.g. -vq -> -v -q
+// The last short flag of a combination may be a flag that expects a value: -vqo=file or -vqofile -> -v -q -o=file
+// Arguments that are defined flags themselves are not split. The expansion stops at the first non-flag argument
+// or at the flag terminator '--'.
+func expandShortFlags(flags *flag.FlagSet, args []string) []string {
+	result := make([]string, 0, len(args))
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if arg == "--" || len(arg) < 2 || arg[0] != '-' {
+			// end of flags
+			return append(result, args[idx:]...)
+		}
 
-	// single key -> last description of that key
-	flagDefinitions := make(map[string]string, len(options))
-	envP := make(map[string]*string, len(options))
+		name := strings.TrimLeft(arg, "-")
+		hasValue := strings.Contains(name, "=")
+		if hasValue {
+			name = name[:strings.Index(name, "=")]
+		}
 
-	for _, opt := range options {
-		if opt.IsAction() {
-			// skip actions that do not have value parsing logic
+		if f := flags.Lookup(name); f != nil || strings.HasPrefix(arg, "--") {
+			result = append(result, arg)
+			if f != nil && !hasValue && !isBoolFlag(f) && idx+1 < len(args) {
+				// next argument is the value of the flag
+				idx++
+				result = append(result, args[idx])
+			}
 			continue
 		}
 
-		_, found := flagDefinitions[opt.Key]
-		if !found || (found && opt.Description != "") {
-			flagDefinitions[opt.Key] = opt.Description
+		expanded, needsValue, ok := expandShortFlag(flags, arg[1:])
+		if !ok {
+			// let the flag package report the unknown flag
+			result = append(result, arg)
+			continue
+		}
+		result = append(result, expanded...)
+		if needsValue && idx+1 < len(args) {
+			idx++
+			result = append(result, args[idx])
 		}
 	}
+	return result
+}
+
+// expandShortFlag expands a single combination of short flags without the leading dash.
+func expandShortFlag(flags *flag.FlagSet, combined string) (expanded []string, needsValue bool, ok bool) {
+	runes := []rune(combined)
+	expanded = make([]string, 0, len(runes))
+	for idx, r := range runes {
+		f := flags.Lookup(string(r))
+		if f == nil {
+			return nil, false, false
+		}
+		if isBoolFlag(f) {
+			expanded = append(expanded, "-"+string(r))
+			continue
+		}
 
-	flags := flag.NewFlagSet("", errHandling)
-	for key, description := range flagDefinitions {
-		flagName := KeyToFlagNameTransformer(key)
-		envP[key] = flags.String(flagName, "", description)
+		// the rest of the combination is the value of the flag
+		rest := string(runes[idx+1:])
+		if rest == "" {
+			return append(expanded, "-"+string(r)), true, true
+		}
+		return append(expanded, "-"+string(r)+"="+strings.TrimPrefix(rest, "=")), false, true
 	}
+	return expanded, false, true
+}
 
-	err := flags.Parse(osArgs)
-	if err != nil {
-		return nil, err
+func isBoolFlag(f *flag.Flag) bool {
+	bf, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
+
+// flagValue contains the raw string value of a flag.
+// Flags of options that do not allow the SourceFlag are defined in order to return a descriptive error.
+type flagValue struct {
+	key               string
+	kind              Kind
+	delimiter         string
+	keyValueDelimiter string
+	notAllowed        bool
+	err               error // returned by parseFlagSet, as the flag package does not wrap errors
+
+	value  string
+	values []string
+}
+
+func (fv *flagValue) String() string {
+	if fv == nil {
+		return ""
 	}
+	return fv.value
+}
 
-	env := make(map[string]string, len(envP))
-	for k, v := range envP {
-		if v != nil && *v != "" {
-			env[k] = *v
+func (fv *flagValue) Set(value string) error {
+	if fv.notAllowed {
+		fv.err = fmt.Errorf("%w: option '%s' must not be set via %s", ErrSourceNotAllowed, fv.key, SourceFlag)
+		return fv.err
+	}
+
+	switch fv.kind {
+	case KindList, KindMap:
+		if fv.kind == KindMap && fv.keyValueDelimiter != "" && !strings.Contains(value, fv.keyValueDelimiter) {
+			value = strings.Replace(value, "=", fv.keyValueDelimiter, 1)
 		}
+		if len(fv.values) > 0 && fv.delimiter == "" {
+			return fmt.Errorf("option '%s' does not define a Delimiter in order to join repeated flags", fv.key)
+		}
+		fv.values = append(fv.values, value)
+		fv.value = strings.Join(fv.values, fv.delimiter)
+	default:
+		fv.value = value
 	}
-	return env, nil
+	return nil
+}
+
+// IsBoolFlag allows boolean flags to be passed without any value: --flag
+func (fv *flagValue) IsBoolFlag() bool {
+	return fv.kind == KindBool
+}
+
+// negatedFlagValue sets the negated boolean value of the target flag: --no-flag
+type negatedFlagValue struct {
+	target *flagValue
+}
+
+func (nfv *negatedFlagValue) String() string {
+	return ""
+}
+
+// Set accepts the same values as parsers.Bool, e.g. --no-flag=yes
+func (nfv *negatedFlagValue) Set(value string) error {
+	b, ok := internal.ParseBool(value)
+	if !ok {
+		return fmt.Errorf("invalid value of type 'bool': %s", value)
+	}
+	return nfv.target.Set(strconv.FormatBool(!b))
+}
+
+func (nfv *negatedFlagValue) IsBoolFlag() bool {
+	return true
 }
@@ -0,0 +1,464 @@
+package configo_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/jxsl13/simple-configo/unparsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type boolFlagCfg struct {
+	debug   bool
+	verbose bool
+	name    string
+}
+
+func (bc *boolFlagCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "DEBUG",
+			DefaultValue:  "false",
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Bool(&bc.debug),
+		},
+		{
+			Key:           "VERBOSE",
+			DefaultValue:  "true",
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Or(parsers.Bool(&bc.verbose)),
+		},
+		{
+			Key:           "NAME",
+			ParseFunction: parsers.String(&bc.name),
+		},
+	}
+}
+
+func TestBoolFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want map[string]string
+	}{
+		{"#1", []string{"--debug"}, map[string]string{"DEBUG": "true"}},
+		{"#2", []string{"--debug=false"}, map[string]string{"DEBUG": "false"}},
+		{"#3", []string{"-debug", "--name", "value"}, map[string]string{"DEBUG": "true", "NAME": "value"}},
+		{"#4", []string{"--no-verbose"}, map[string]string{"VERBOSE": "false"}},
+		{"#5", []string{"--verbose", "--no-verbose=false"}, map[string]string{"VERBOSE": "true"}},
+		{"#6", []string{}, map[string]string{}},
+		{"#7", []string{"--no-verbose=yes"}, map[string]string{"VERBOSE": "false"}},
+		{"#8", []string{"--no-verbose=DISABLED"}, map[string]string{"VERBOSE": "true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := configo.GetFlagMap(tt.args, &boolFlagCfg{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	cfg := &boolFlagCfg{}
+	flagMap, err := configo.GetFlagMap([]string{"--debug", "--no-verbose"}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, configo.Parse(flagMap, cfg))
+	assert.True(t, cfg.debug)
+	assert.False(t, cfg.verbose)
+
+	_, err = configo.GetFlagMap([]string{"--name"}, cfg)
+	assert.Error(t, err)
+	_, err = configo.GetFlagMap([]string{"--no-verbose=maybe"}, cfg)
+	assert.Error(t, err)
+}
+
+type repeatedFlagCfg struct {
+	delimiter         string
+	pairDelimiter     string
+	keyValueDelimiter string
+	peers             []string
+	set               map[string]bool
+	labels            map[string]string
+	names             string
+}
+
+func (rc *repeatedFlagCfg) Options() configo.Options {
+	rc.delimiter = ","
+	rc.pairDelimiter = ";"
+	rc.keyValueDelimiter = "->"
+	return configo.Options{
+		{
+			Key:           "PEER",
+			Kind:          configo.KindList,
+			Delimiter:     rc.delimiter,
+			ParseFunction: parsers.List(&rc.peers, &rc.delimiter),
+		},
+		{
+			Key:           "SET",
+			Kind:          configo.KindList,
+			Delimiter:     rc.delimiter,
+			ParseFunction: parsers.ListToSet(&rc.set, &rc.delimiter),
+		},
+		{
+			Key:               "LABEL",
+			Mandatory:         true,
+			Kind:              configo.KindMap,
+			Delimiter:         rc.pairDelimiter,
+			KeyValueDelimiter: rc.keyValueDelimiter,
+			ParseFunction:     parsers.Map(&rc.labels, &rc.pairDelimiter, &rc.keyValueDelimiter),
+		},
+		{
+			// does not define any Delimiter
+			Key:           "NAMES",
+			Kind:          configo.KindList,
+			ParseFunction: parsers.String(&rc.names),
+		},
+	}
+}
+
+func TestRepeatedFlags(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &repeatedFlagCfg{}
+	flagMap, err := configo.GetFlagMap([]string{
+		"--peer", "a", "--peer=b,c",
+		"--set", "x,y",
+		"--label", "k=v", "--label", "k2=v=2", "--label", "k3->v3",
+	}, cfg)
+	require.NoError(t, err)
+	assert.Equal(map[string]string{
+		"PEER":  "a,b,c",
+		"SET":   "x,y",
+		"LABEL": "k->v;k2->v=2;k3->v3",
+	}, flagMap)
+
+	require.NoError(t, configo.Parse(flagMap, cfg))
+	assert.Equal([]string{"a", "b", "c"}, cfg.peers)
+	assert.Equal(map[string]bool{"x": true, "y": true}, cfg.set)
+	assert.Equal(map[string]string{"k": "v", "k2": "v=2", "k3": "v3"}, cfg.labels)
+
+	// repeated flags require a delimiter
+	_, err = configo.GetFlagMap([]string{"--names", "x", "--names", "y"}, cfg)
+	assert.Error(err)
+}
+
+type shortFlagCfg struct {
+	verbose bool
+	quiet   bool
+	output  string
+	name    string
+}
+
+func (sc *shortFlagCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "APP_VERBOSE",
+			FlagName:      "verbose",
+			ShortFlag:     "v",
+			DefaultValue:  "false",
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Bool(&sc.verbose),
+		},
+		{
+			Key:           "APP_QUIET",
+			ShortFlag:     "q",
+			DefaultValue:  "false",
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Bool(&sc.quiet),
+		},
+		{
+			Key:           "APP_OUTPUT",
+			ShortFlag:     "o",
+			ParseFunction: parsers.String(&sc.output),
+		},
+		{
+			Key:           "APP_NAME",
+			FlagName:      "n",
+			ParseFunction: parsers.String(&sc.name),
+		},
+	}
+}
+
+func TestShortFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"#1", []string{"-v"}, map[string]string{"APP_VERBOSE": "true"}, false},
+		{"#2", []string{"--verbose", "--app-quiet"}, map[string]string{"APP_VERBOSE": "true", "APP_QUIET": "true"}, false},
+		{"#3", []string{"-vq"}, map[string]string{"APP_VERBOSE": "true", "APP_QUIET": "true"}, false},
+		{"#4", []string{"-vqo", "file"}, map[string]string{"APP_VERBOSE": "true", "APP_QUIET": "true", "APP_OUTPUT": "file"}, false},
+		{"#5", []string{"-vofile"}, map[string]string{"APP_VERBOSE": "true", "APP_OUTPUT": "file"}, false},
+		{"#6", []string{"-qo=-vq"}, map[string]string{"APP_QUIET": "true", "APP_OUTPUT": "-vq"}, false},
+		{"#7", []string{"-n", "-vq"}, map[string]string{"APP_NAME": "-vq"}, false},
+		{"#8", []string{"-v", "--", "-q"}, map[string]string{"APP_VERBOSE": "true"}, false},
+		{"#9", []string{"-vx"}, nil, true},
+		{"#10", []string{"--app-verbose"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := configo.GetFlagMap(tt.args, &shortFlagCfg{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type collisionCfg struct {
+	options configo.Options
+}
+
+func (cc *collisionCfg) Options() configo.Options {
+	return cc.options
+}
+
+func TestFlagNameCollisions(t *testing.T) {
+	var (
+		s string
+		b bool
+	)
+	tests := []struct {
+		name    string
+		options configo.Options
+		wantErr error
+		wantMsg string
+	}{
+		{
+			"underscores",
+			configo.Options{
+				{Key: "DB_HOST", ParseFunction: parsers.String(&s)},
+				{Key: "DB__HOST", ParseFunction: parsers.String(&s)},
+			},
+			configo.ErrFlagNameCollision,
+			"--db-host: DB_HOST, DB__HOST",
+		},
+		{
+			"short flags",
+			configo.Options{
+				{Key: "VERBOSE", ShortFlag: "v", Kind: configo.KindBool, ParseFunction: parsers.Bool(&b)},
+				{Key: "VERSION", ShortFlag: "v", ParseFunction: parsers.String(&s)},
+			},
+			configo.ErrFlagNameCollision,
+			"-v: VERBOSE (short flag), VERSION (short flag)",
+		},
+		{
+			"negation",
+			configo.Options{
+				{Key: "CACHE", Kind: configo.KindBool, ParseFunction: parsers.Bool(&b)},
+				{Key: "NO_CACHE", ParseFunction: parsers.String(&s)},
+			},
+			configo.ErrFlagNameCollision,
+			"--no-cache: CACHE (negation), NO_CACHE",
+		},
+		{
+			"built-in help flag",
+			configo.Options{
+				{Key: "HOST", ShortFlag: "h", ParseFunction: parsers.String(&s)},
+			},
+			configo.ErrFlagNameCollision,
+			"-h: HOST (short flag), built-in help flag",
+		},
+		{
+			"empty flag name",
+			configo.Options{
+				{Key: "...", ParseFunction: parsers.String(&s)},
+			},
+			configo.ErrInvalidFlagName,
+			"'' of key ...",
+		},
+		{
+			"long short flag",
+			configo.Options{
+				{Key: "VERBOSE", ShortFlag: "ab", Kind: configo.KindBool, ParseFunction: parsers.Bool(&b)},
+			},
+			configo.ErrInvalidFlagName,
+			"short flag 'ab' of key VERBOSE",
+		},
+		{
+			"dash short flag",
+			configo.Options{
+				{Key: "VERBOSE", ShortFlag: "-v", Kind: configo.KindBool, ParseFunction: parsers.Bool(&b)},
+				{Key: "QUIET", ShortFlag: "-", Kind: configo.KindBool, ParseFunction: parsers.Bool(&b)},
+			},
+			configo.ErrInvalidFlagName,
+			"short flag '-v' of key VERBOSE, short flag '-' of key QUIET",
+		},
+		{
+			"equals short flag",
+			configo.Options{
+				{Key: "NAME", ShortFlag: "x=", ParseFunction: parsers.String(&s)},
+				{Key: "HOST", ShortFlag: "=", ParseFunction: parsers.String(&s)},
+			},
+			configo.ErrInvalidFlagName,
+			"short flag 'x=' of key NAME, short flag '=' of key HOST",
+		},
+		{
+			"unicode short flag",
+			configo.Options{
+				{Key: "VERBOSE", ShortFlag: "ü", Kind: configo.KindBool, ParseFunction: parsers.Bool(&b)},
+				{Key: "VERSION", ShortFlag: "ü", ParseFunction: parsers.String(&s)},
+			},
+			configo.ErrFlagNameCollision,
+			"-ü: VERBOSE (short flag), VERSION (short flag)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &collisionCfg{tt.options}
+			_, err := configo.GetFlagMap([]string{}, cfg)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+
+			err = configo.CheckFlags(cfg)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Panics(t, func() { configo.GetFlagSet("app", flag.ContinueOnError, cfg) })
+		})
+	}
+}
+
+func TestFlagNameCollisionsTransformer(t *testing.T) {
+	defer func(transformer func(string) string) {
+		configo.KeyToFlagNameTransformer = transformer
+	}(configo.KeyToFlagNameTransformer)
+	configo.KeyToFlagNameTransformer = func(string) string {
+		return "same"
+	}
+
+	_, err := configo.GetFlagMap([]string{}, &boolFlagCfg{})
+	require.ErrorIs(t, err, configo.ErrFlagNameCollision)
+	assert.Contains(t, err.Error(), "--same: DEBUG, NAME, VERBOSE")
+}
+
+type unparseFlagsCfg struct {
+	debug  bool
+	name   string
+	peers  []string
+	labels map[string]string
+	token  string
+}
+
+func (uc *unparseFlagsCfg) Options() configo.Options {
+	delimiter := ","
+	keyValueDelimiter := ":"
+	return configo.Options{
+		{
+			Key:             "DEBUG",
+			DefaultValue:    "false",
+			Kind:            configo.KindBool,
+			ParseFunction:   parsers.Bool(&uc.debug),
+			UnparseFunction: unparsers.Bool(&uc.debug),
+		},
+		{
+			Key:             "NAME",
+			ParseFunction:   parsers.String(&uc.name),
+			UnparseFunction: unparsers.String(&uc.name),
+		},
+		{
+			Key:             "PEERS",
+			Kind:            configo.KindList,
+			Delimiter:       delimiter,
+			ParseFunction:   parsers.List(&uc.peers, &delimiter),
+			UnparseFunction: unparsers.List(&uc.peers, &delimiter),
+		},
+		{
+			Key:               "LABELS",
+			Mandatory:         true,
+			Kind:              configo.KindMap,
+			Delimiter:         delimiter,
+			KeyValueDelimiter: keyValueDelimiter,
+			ParseFunction:     parsers.Map(&uc.labels, &delimiter, &keyValueDelimiter),
+			UnparseFunction:   unparsers.Map(&uc.labels, &delimiter, &keyValueDelimiter),
+		},
+		{
+			Key:           "TOKEN",
+			ParseFunction: parsers.String(&uc.token),
+			UnparseFunction: func() (string, error) {
+				return "", configo.ErrSkipUnparse
+			},
+		},
+	}
+}
+
+func TestUnparseFlags(t *testing.T) {
+	cfg := &unparseFlagsCfg{
+		debug:  true,
+		name:   "it's me",
+		peers:  []string{"a", "b"},
+		labels: map[string]string{"env": "prod"},
+		token:  "secret",
+	}
+	args, err := configo.UnparseFlags(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--debug=true", "--name=it's me", "--peers=a,b", "--labels=env:prod"}, args)
+	assert.Equal(t, `--debug=true '--name=it'\''s me' --peers=a,b --labels=env:prod`, configo.ShellJoin(args))
+
+	env, err := configo.Unparse(cfg)
+	require.NoError(t, err)
+	flagMap, err := configo.GetFlagMap(args, &unparseFlagsCfg{})
+	require.NoError(t, err)
+	assert.Equal(t, env, flagMap)
+
+	parsed := &unparseFlagsCfg{}
+	require.NoError(t, configo.Parse(flagMap, parsed))
+	assert.Equal(t, cfg.name, parsed.name)
+	assert.Equal(t, cfg.peers, parsed.peers)
+	assert.Equal(t, cfg.labels, parsed.labels)
+}
+
+func TestParseFlagsArgs(t *testing.T) {
+	defer func(args []string) {
+		os.Args = args
+	}(os.Args)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantArgs   []string
+		wantDebug  bool
+		wantName   string
+		wantErrMsg string
+	}{
+		{"#1", []string{"--debug", "a.txt", "b.txt"}, []string{"a.txt", "b.txt"}, true, "", ""},
+		{"#2", []string{"--name", "n", "--", "-a.txt", "--debug"}, []string{"-a.txt", "--debug"}, false, "n", ""},
+		{"#3", []string{"--debug", "--"}, []string{}, true, "", ""},
+		{"#4", []string{"a.txt", "--debug"}, []string{"a.txt", "--debug"}, false, "", ""},
+		{"#5", []string{"-", "--debug"}, []string{"-", "--debug"}, false, "", ""},
+		{"#6", []string{"--unknown", "a.txt"}, nil, false, "", "flag provided but not defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{"app"}, tt.args...)
+
+			parseFuncs := map[string]func(cfg configo.Config) ([]string, error){
+				"ParseFlagsArgs":      func(cfg configo.Config) ([]string, error) { return configo.ParseFlagsArgs(cfg) },
+				"ParseEnvOrFlagsArgs": func(cfg configo.Config) ([]string, error) { return configo.ParseEnvOrFlagsArgs(cfg) },
+				"ParseEnvFileOrEnvOrFlagsArgs": func(cfg configo.Config) ([]string, error) {
+					return configo.ParseEnvFileOrEnvOrFlagsArgs(filepath.Join(t.TempDir(), ".env"), cfg)
+				},
+			}
+			for name, parse := range parseFuncs {
+				cfg := &boolFlagCfg{}
+				args, err := parse(cfg)
+				if tt.wantErrMsg != "" {
+					require.Error(t, err, name)
+					assert.Contains(t, err.Error(), tt.wantErrMsg, name)
+					continue
+				}
+				require.NoError(t, err, name)
+				assert.Equal(t, tt.wantArgs, args, name)
+				assert.Equal(t, tt.wantDebug, cfg.debug, name)
+				assert.Equal(t, tt.wantName, cfg.name, name)
+			}
+		})
+	}
+}
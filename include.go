@@ -0,0 +1,232 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+const (
+	includeDirective         = "#include"
+	includeOptionalDirective = "#include-optional"
+)
+
+var (
+	// ErrIncludeCycle is returned when env files include each other.
+	ErrIncludeCycle = errors.New("include cycle detected")
+)
+
+// envInclude is an #include or #include-optional directive of an env file.
+type envInclude struct {
+	Path     string
+	Optional bool
+}
+
+func (ei *envInclude) String() string {
+	if ei.Optional {
+		return includeOptionalDirective + " " + ei.Path
+	}
+	return includeDirective + " " + ei.Path
+}
+
+// envFilePart is either a block of key value pairs or an include directive.
+// The parts of an env file are kept in the order of their appearance.
+type envFilePart struct {
+	env     map[string]string
+	include *envInclude
+}
+
+// ReadEnvFileProvenance reads the env file and all of its included env files.
+// Additionally to the key value map, a map is returned that contains the path of the file
+// that defined the resulting value of every key.
+// Env files may include other env files with the directives
+//
+//	#include other.env
+//	#include-optional local.env
+//
+// Relative paths are resolved relative to the directory of the including file.
+// Included values override the values that are defined before the directive and are overridden
+// by the values that are defined after the directive.
+// Missing optional files are skipped, missing files of #include directives result in an error.
+func ReadEnvFileProvenance(filePathOrEnvKey string) (env map[string]string, provenance map[string]string, err error) {
+	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
+	return readEnvFileProvenance(filePath)
+}
+
+func readEnvFileProvenance(filePath string) (map[string]string, map[string]string, error) {
+	env := make(map[string]string)
+	provenance := make(map[string]string)
+	err := resolveEnvFile(filepath.Clean(filePath), nil, nil, env, provenance)
+	if err != nil {
+		return nil, nil, err
+	}
+	return env, provenance, nil
+}
+
+// resolveEnvFile reads the file at filePath and recursively resolves its include directives.
+// chain contains the files that included the current file, absChain their absolute paths.
+func resolveEnvFile(filePath string, chain, absChain []string, env, provenance map[string]string) error {
+	chain = append(chain, filePath)
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(chain, " -> "), err)
+	}
+	if internal.Contains(absChain, absPath) {
+		return fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(chain, " -> "))
+	}
+	absChain = append(absChain, absPath)
+
+	parts, err := loadEnvFile(filePath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(chain, " -> "), err)
+	}
+
+	for _, part := range parts {
+		if part.include == nil {
+			for k, v := range part.env {
+				env[k] = v
+				provenance[k] = filePath
+			}
+			continue
+		}
+
+		includePath := part.include.Path
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(filePath), includePath)
+		}
+		if part.include.Optional && !internal.Exists(includePath) {
+			continue
+		}
+		err = resolveEnvFile(includePath, chain, absChain, env, provenance)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadEnvFile reads a single env file, verifies its signature and splits its content into parts
+// without resolving any include directives.
+func loadEnvFile(filePath string) ([]envFilePart, error) {
+	content, err := internal.Load(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return verifiedEnvFileParts(filePath, content, SignatureRequired)
+}
+
+// parseEnvFileParts splits the content of an env file at its include directives and parses
+// the key value pairs in between.
+func parseEnvFileParts(content string) ([]envFilePart, error) {
+	var (
+		parts []envFilePart
+		block strings.Builder
+	)
+
+	flush := func() error {
+		if block.Len() == 0 {
+			return nil
+		}
+		env, err := godotenv.Unmarshal(block.String())
+		if err != nil {
+			return err
+		}
+		block.Reset()
+		if len(env) > 0 {
+			parts = append(parts, envFilePart{env: env})
+		}
+		return nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		include, ok := parseIncludeDirective(line)
+		if !ok {
+			block.WriteString(line)
+			block.WriteString("\n")
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		parts = append(parts, envFilePart{include: include})
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+func parseIncludeDirective(line string) (*envInclude, bool) {
+	line = strings.TrimSpace(line)
+
+	var include envInclude
+	switch {
+	case strings.HasPrefix(line, includeOptionalDirective+" "):
+		include.Path = strings.TrimPrefix(line, includeOptionalDirective)
+		include.Optional = true
+	case strings.HasPrefix(line, includeDirective+" "):
+		include.Path = strings.TrimPrefix(line, includeDirective)
+	default:
+		return nil, false
+	}
+
+	include.Path = strings.TrimSpace(include.Path)
+	if len(include.Path) > 1 && (include.Path[0] == '"' || include.Path[0] == '\'') && include.Path[0] == include.Path[len(include.Path)-1] {
+		include.Path = include.Path[1 : len(include.Path)-1]
+	}
+	if include.Path == "" {
+		return nil, false
+	}
+	return &include, true
+}
+
+// updateEnvFileParts changes the value of every key in each block that defines it.
+// Keys that are not defined by the file are appended to the end of the file, which keeps
+// the position of the keys relative to the include directives.
+func updateEnvFileParts(parts []envFilePart, env map[string]string) []envFilePart {
+	missing := make(map[string]string)
+	for k, v := range env {
+		found := false
+		for _, part := range parts {
+			if _, ok := part.env[k]; ok {
+				part.env[k] = v
+				found = true
+			}
+		}
+		if !found {
+			missing[k] = v
+		}
+	}
+	if len(missing) == 0 {
+		return parts
+	}
+
+	if last := len(parts) - 1; last >= 0 && parts[last].include == nil {
+		for k, v := range missing {
+			parts[last].env[k] = v
+		}
+		return parts
+	}
+	return append(parts, envFilePart{env: missing})
+}
+
+// marshalEnvFile serializes the parts in their order, the key value pairs of every block are sorted.
+func marshalEnvFile(parts []envFilePart) (string, error) {
+	lines := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part.include != nil {
+			lines = append(lines, part.include.String())
+			continue
+		}
+		content, err := godotenv.Marshal(part.env)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, content)
+	}
+	return strings.Join(lines, "\n"), nil
+}
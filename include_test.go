@@ -0,0 +1,120 @@
+package configo_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0700))
+		require.NoError(t, ioutil.WriteFile(filePath, []byte(content), 0600))
+	}
+}
+
+func TestEnvFileInclude(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".env": `DB_HOST=overridden
+#include shared/common.env
+#include-optional local.env
+DB_USER=app
+`,
+		"shared/common.env": `DB_HOST=db.example.com
+DB_PORT=5432
+#include ../local.env
+`,
+		"local.env": `DB_PORT=6543
+`,
+	})
+
+	env, provenance, err := configo.ReadEnvFileProvenance(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Equal(map[string]string{
+		"DB_HOST": "db.example.com",
+		"DB_PORT": "6543",
+		"DB_USER": "app",
+	}, env)
+	assert.Equal(map[string]string{
+		"DB_HOST": filepath.Join(dir, "shared", "common.env"),
+		"DB_PORT": filepath.Join(dir, "local.env"),
+		"DB_USER": filepath.Join(dir, ".env"),
+	}, provenance)
+
+	// directives are kept in place when the file is updated
+	require.NoError(t, configo.UpdateEnvFile(map[string]string{"DB_USER": "root", "DB_NAME": "app"}, filepath.Join(dir, ".env")))
+	env, err = configo.ReadEnvFile(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Equal("db.example.com", env["DB_HOST"])
+	assert.Equal("6543", env["DB_PORT"])
+	assert.Equal("root", env["DB_USER"])
+	assert.Equal("app", env["DB_NAME"])
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Equal(`DB_HOST="overridden"
+#include shared/common.env
+#include-optional local.env
+DB_NAME="app"
+DB_USER="root"
+`, string(b))
+}
+
+func TestEnvFileIncludeErrors(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.env":       "#include b.env\n",
+		"b.env":       "#include a.env\n",
+		"missing.env": "#include-optional optional.env\n#include required.env\n",
+	})
+
+	_, err := configo.ReadEnvFile(filepath.Join(dir, "a.env"))
+	assert.True(errors.Is(err, configo.ErrIncludeCycle))
+	assert.Contains(err.Error(), filepath.Join(dir, "a.env")+" -> "+filepath.Join(dir, "b.env")+" -> "+filepath.Join(dir, "a.env"))
+
+	_, err = configo.ReadEnvFile(filepath.Join(dir, "missing.env"))
+	assert.True(errors.Is(err, os.ErrNotExist))
+	assert.Contains(err.Error(), filepath.Join(dir, "missing.env")+" -> "+filepath.Join(dir, "required.env"))
+
+	// a missing include must not be mistaken for a missing env file
+	err = configo.ParseEnvFileOrEnv(filepath.Join(dir, "missing.env"), &kubernetesCfg{})
+	assert.True(errors.Is(err, os.ErrNotExist))
+}
+
+func TestSignedEnvFileInclude(t *testing.T) {
+	setenv(t, configo.SignatureKeyEnvKey, "signing key")
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".env": "#include other.env\nDB_HOST=localhost\n",
+	})
+	filePath := filepath.Join(dir, ".env")
+	require.NoError(t, configo.SignEnvFile(filePath))
+	require.NoError(t, configo.VerifyEnvFile(filePath))
+
+	// include directives are part of the signature
+	b, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filePath, append([]byte("#include evil.env\n"), b...), 0600))
+	assert.True(t, errors.Is(configo.VerifyEnvFile(filePath), configo.ErrInvalidSignature))
+
+	// as well as their position relative to the keys
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("#include other.env\nDB_HOST=localhost\n"), 0600))
+	require.NoError(t, configo.SignEnvFile(filePath))
+	b, err = ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	reordered := strings.Replace(string(b), "#include other.env\nDB_HOST=localhost\n", "DB_HOST=localhost\n#include other.env\n", 1)
+	require.NotEqual(t, string(b), reordered)
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(reordered), 0600))
+	assert.True(t, errors.Is(configo.VerifyEnvFile(filePath), configo.ErrInvalidSignature))
+}
@@ -0,0 +1,36 @@
+package internal
+
+var (
+	// map of valid bool values that can be used in configs
+	boolValues = map[string]bool{
+		"0":        false,
+		"1":        true,
+		"true":     true,
+		"TRUE":     true,
+		"false":    false,
+		"FALSE":    false,
+		"enabled":  true,
+		"ENABLED":  true,
+		"disabled": false,
+		"DISABLED": false,
+		"y":        true,
+		"Y":        true,
+		"yes":      true,
+		"YES":      true,
+		"n":        false,
+		"N":        false,
+		"no":       false,
+		"NO":       false,
+		"enable":   true,
+		"ENABLE":   true,
+		"disable":  false,
+		"DISABLE":  false,
+	}
+)
+
+// ParseBool parses the bool values that are accepted in configs, e.g. true, 1, yes, y or enabled.
+// ok is false in case that the value is not a valid bool value.
+func ParseBool(value string) (b bool, ok bool) {
+	b, ok = boolValues[value]
+	return b, ok
+}
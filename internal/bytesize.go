@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// byteUnit is a unit of byte sizes, either SI (1000^n) or IEC (1024^n).
+type byteUnit struct {
+	name   string
+	factor uint64
+}
+
+var (
+	// ascending order, used for formatting
+	byteUnits = []byteUnit{
+		{"B", 1},
+		{"kB", 1e3},
+		{"KiB", 1 << 10},
+		{"MB", 1e6},
+		{"MiB", 1 << 20},
+		{"GB", 1e9},
+		{"GiB", 1 << 30},
+		{"TB", 1e12},
+		{"TiB", 1 << 40},
+		{"PB", 1e15},
+		{"PiB", 1 << 50},
+		{"EB", 1e18},
+		{"EiB", 1 << 60},
+	}
+
+	// lower case unit names and their factors, used for parsing
+	byteUnitFactors = map[string]uint64{
+		"": 1, "b": 1,
+		"k": 1e3, "kb": 1e3, "kib": 1 << 10,
+		"m": 1e6, "mb": 1e6, "mib": 1 << 20,
+		"g": 1e9, "gb": 1e9, "gib": 1 << 30,
+		"t": 1e12, "tb": 1e12, "tib": 1 << 40,
+		"p": 1e15, "pb": 1e15, "pib": 1 << 50,
+		"e": 1e18, "eb": 1e18, "eib": 1 << 60,
+	}
+)
+
+// ParseByteSize parses a byte size like 512MiB, 1.5GB, 10k or 1024.
+// SI units (k, kB, MB, ...) are powers of 1000, IEC units (KiB, MiB, ...) are powers of 1024.
+// Units are case insensitive and may be separated from the number by spaces.
+// The size must be a whole number of bytes that fits into an uint64.
+func ParseByteSize(value string) (uint64, error) {
+	s := strings.TrimSpace(value)
+	idx := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if idx < 0 {
+		idx = len(s)
+	}
+	number, unit := s[:idx], strings.ToLower(strings.TrimSpace(s[idx:]))
+
+	factor, ok := byteUnitFactors[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit '%s'", strconv.ErrSyntax, s[idx:])
+	}
+	size, ok := new(big.Rat).SetString(number)
+	if number == "" || !ok {
+		return 0, strconv.ErrSyntax
+	}
+
+	size.Mul(size, new(big.Rat).SetInt(new(big.Int).SetUint64(factor)))
+	if !size.IsInt() {
+		return 0, fmt.Errorf("%w: not a whole number of bytes", strconv.ErrSyntax)
+	}
+	if size.Num().BitLen() > 64 {
+		return 0, strconv.ErrRange
+	}
+	return size.Num().Uint64(), nil
+}
+
+// FormatByteSize returns the shortest exact representation of the byte size, e.g. 512MiB or 1.5GB.
+// In case that multiple representations have the same length, the one with the smaller unit is used.
+func FormatByteSize(size uint64) string {
+	result := ""
+	for _, unit := range byteUnits {
+		if unit.factor > size && size != 0 {
+			break
+		}
+		r := new(big.Rat).SetFrac(new(big.Int).SetUint64(size), new(big.Int).SetUint64(unit.factor))
+		number, ok := exactDecimal(r)
+		if !ok {
+			continue
+		}
+		if s := number + unit.name; result == "" || len(s) < len(result) {
+			result = s
+		}
+	}
+	return result
+}
+
+// exactDecimal returns the decimal representation of r in case that it has a finite number of digits.
+func exactDecimal(r *big.Rat) (string, bool) {
+	if r.IsInt() {
+		return r.Num().String(), true
+	}
+
+	// only fractions whose denominator consists of the prime factors 2 and 5 have a finite representation
+	denom := new(big.Int).Set(r.Denom())
+	digits := 0
+	for _, prime := range []int64{2, 5} {
+		p := big.NewInt(prime)
+		count := 0
+		for new(big.Int).Mod(denom, p).Sign() == 0 {
+			denom.Div(denom, p)
+			count++
+		}
+		if count > digits {
+			digits = count
+		}
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return "", false
+	}
+	return strings.TrimRight(r.FloatString(digits), "0"), true
+}
@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Day is the duration of 24 hours, daylight saving time is not taken into account.
+	Day = 24 * time.Hour
+	// Week is the duration of 7 days.
+	Week = 7 * Day
+)
+
+var (
+	// P[nW][nD][T[nH][nM][nS]], years and months are matched in order to return a proper error.
+	isoDurationRegex = regexp.MustCompile(`^([+-])?P(?:([0-9.,]+)Y)?(?:([0-9.,]+)M)?(?:([0-9.,]+)W)?(?:([0-9.,]+)D)?(?:T(?:([0-9.,]+)H)?(?:([0-9.,]+)M)?(?:([0-9.,]+)S)?)?$`)
+
+	// units of the compact duration format in descending order
+	durationUnits = []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"w", Week},
+		{"d", Day},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	}
+
+	errDurationRange = errors.New("duration out of range")
+)
+
+// ParseDuration parses durations in the format of time.ParseDuration that may additionally contain
+// the units d (24h) and w (7d), e.g. 7d, 2w, 1w2d12h or 1.5d.
+// ISO-8601 durations like P1DT2H, PT30M or P2W are accepted as well. As years and months do not have a
+// fixed duration, they are not supported.
+func ParseDuration(value string) (time.Duration, error) {
+	if strings.HasPrefix(strings.TrimLeft(value, "+-"), "P") {
+		return parseISODuration(value)
+	}
+
+	s := value
+	negative := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+
+	var total time.Duration
+	for s != "" {
+		idx := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if idx <= 0 {
+			return 0, fmt.Errorf("invalid duration '%s'", value)
+		}
+		end := strings.IndexFunc(s[idx:], func(r rune) bool {
+			return (r >= '0' && r <= '9') || r == '.'
+		})
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += idx
+		}
+
+		d, err := parseDurationSegment(s[:idx], s[idx:end])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s': %w", value, err)
+		}
+		total, err = addDuration(total, d)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s': %w", value, err)
+		}
+		s = s[end:]
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// parseISODuration parses an ISO-8601 duration like P1W, P1DT2H30M or PT0.5S.
+func parseISODuration(value string) (time.Duration, error) {
+	match := isoDurationRegex.FindStringSubmatch(value)
+	if match == nil || value[len(value)-1] == 'P' || value[len(value)-1] == 'T' {
+		return 0, fmt.Errorf("invalid ISO-8601 duration '%s'", value)
+	}
+	if match[2] != "" || match[3] != "" {
+		return 0, fmt.Errorf("invalid ISO-8601 duration '%s': years and months are not supported", value)
+	}
+
+	var total time.Duration
+	for idx, unit := range []string{"w", "d", "h", "m", "s"} {
+		number := strings.Replace(match[idx+4], ",", ".", 1)
+		if number == "" {
+			continue
+		}
+		d, err := parseDurationSegment(number, unit)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO-8601 duration '%s': %w", value, err)
+		}
+		total, err = addDuration(total, d)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO-8601 duration '%s': %w", value, err)
+		}
+	}
+
+	if match[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
+
+// parseDurationSegment parses a single number and its unit.
+func parseDurationSegment(number, unit string) (time.Duration, error) {
+	factor := time.Duration(1)
+	switch unit {
+	case "w":
+		unit, factor = "h", 7*24
+	case "d":
+		unit, factor = "h", 24
+	}
+
+	d, err := time.ParseDuration(number + unit)
+	if err != nil {
+		return 0, err
+	}
+	if d > math.MaxInt64/factor {
+		return 0, errDurationRange
+	}
+	return d * factor, nil
+}
+
+func addDuration(a, b time.Duration) (time.Duration, error) {
+	if a > math.MaxInt64-b {
+		return 0, errDurationRange
+	}
+	return a + b, nil
+}
+
+// FormatDuration returns the representation of the duration with the largest units out of
+// w, d, h, m, s, ms, µs and ns, e.g. 2w, 10d, 9d12h, 1d12h, 1h30m or 1.5s.
+// Weeks are only used for whole weeks, otherwise the largest unit is days.
+// The result can be parsed with ParseDuration.
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	if d == math.MinInt64 {
+		// cannot be negated
+		return d.String()
+	}
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	// 9d12h instead of 1w2d12h
+	if d%Week != 0 {
+		return sign + formatDurationFrom(d, 1)
+	}
+	return sign + formatDurationFrom(d, 0)
+}
+
+// formatDurationFrom formats the duration with the units starting at the index top of the durationUnits.
+func formatDurationFrom(d time.Duration, top int) string {
+	var sb strings.Builder
+	for _, unit := range durationUnits[top:] {
+		if n := d / unit.duration; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10) + unit.name)
+			d -= n * unit.duration
+		}
+	}
+	if d == 0 {
+		return sb.String()
+	}
+
+	if d < time.Second {
+		// ms, µs or ns
+		sb.WriteString(d.String())
+		return sb.String()
+	}
+	seconds := strconv.FormatInt(int64(d/time.Second), 10)
+	if fraction := d % time.Second; fraction > 0 {
+		seconds += strings.TrimRight(fmt.Sprintf(".%09d", int64(fraction)), "0")
+	}
+	sb.WriteString(seconds + "s")
+	return sb.String()
+}
@@ -6,6 +6,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
+	"time"
 )
 
 // Exists reports whether the named file or directory exists.
@@ -52,6 +54,43 @@ func Save(text, filePath string, perm ...fs.FileMode) error {
 	return ioutil.WriteFile(filePath, []byte(text), mode)
 }
 
+// WriteFileAtomic writes the data into a temporary file next to the filePath and renames it afterwards.
+// Readers either see the old or the new content of the file, but never a partially written file.
+// Existing files keep their permissions, new files are created with perm (before umask).
+func WriteFileAtomic(filePath string, data []byte, perm fs.FileMode) (err error) {
+	tmpPath := filePath + ".tmp-" + strconv.Itoa(os.Getpid()) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if fi, statErr := os.Stat(filePath); statErr == nil {
+		err = f.Chmod(fi.Mode().Perm())
+		if err != nil {
+			return err
+		}
+	}
+	_, err = f.Write(data)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
 // Load allows to load a text from a given filePath that points to a file
 // which contains the text
 func Load(filePath string) (text string, err error) {
@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxZoneSuggestions = 3
+
+var (
+	// +02:00, +0200, -5, UTC+2, GMT-03:30
+	fixedZoneRegex = regexp.MustCompile(`^(?i:UTC|GMT)?([+-])([0-9]{1,2})(?::?([0-9]{2}))?$`)
+
+	// directories that usually contain the IANA time zone database, see time.LoadLocation
+	zoneInfoDirs = []string{
+		"/usr/share/zoneinfo/",
+		"/usr/share/lib/zoneinfo/",
+		"/usr/lib/locale/TZ/",
+	}
+
+	zoneNamesOnce sync.Once
+	zoneNames     []string
+)
+
+// ParseLocation parses an IANA time zone name like Europe/Berlin, UTC or Local as well as
+// fixed offsets like +02:00, -0530, +2 or UTC+2.
+// Fixed offsets are named after their normalized offset, e.g. +02:00.
+// In case that the zone is unknown, the error contains suggestions of similar zone names.
+func ParseLocation(value string) (*time.Location, error) {
+	if loc, ok, err := parseFixedZone(value); ok {
+		return loc, err
+	}
+
+	loc, err := time.LoadLocation(value)
+	if err == nil {
+		return loc, nil
+	}
+	if suggestions := SuggestZoneNames(value); len(suggestions) > 0 {
+		return nil, fmt.Errorf("unknown time zone, did you mean: %s", strings.Join(suggestions, ", "))
+	}
+	return nil, err
+}
+
+// parseFixedZone parses fixed offsets. ok is false in case that the value is not an offset.
+func parseFixedZone(value string) (loc *time.Location, ok bool, err error) {
+	match := fixedZoneRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return nil, false, nil
+	}
+	hours, _ := strconv.Atoi(match[2])
+	minutes := 0
+	if match[3] != "" {
+		minutes, _ = strconv.Atoi(match[3])
+	}
+	offset := hours*60*60 + minutes*60
+	if match[1] == "-" {
+		offset = -offset
+	}
+	// real offsets range from -12:00 to +14:00
+	if minutes >= 60 || offset < -12*60*60 || offset > 14*60*60 {
+		return nil, true, fmt.Errorf("time zone offset out of range, expected -12:00 to +14:00")
+	}
+	return time.FixedZone(fmt.Sprintf("%s%02d:%02d", match[1], hours, minutes), offset), true, nil
+}
+
+// SuggestZoneNames returns up to three known zone names that are similar to the passed value.
+// Case insensitive matches of the whole name or its city part are preferred, e.g. berlin or
+// new york for Europe/Berlin and America/New_York, otherwise the zone names with the smallest edit distance
+// are returned.
+func SuggestZoneNames(value string) []string {
+	names := knownZoneNames()
+	needle := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), " ", "_"))
+	if needle == "" {
+		return nil
+	}
+
+	result := make([]string, 0, maxZoneSuggestions)
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		if lower == needle || strings.HasSuffix(lower, "/"+needle) {
+			result = append(result, name)
+		}
+	}
+	if len(result) > 0 {
+		return limitStrings(result, maxZoneSuggestions)
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	maxDistance := len(needle) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	candidates := make([]candidate, 0)
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		distance := levenshtein(needle, lower)
+		if idx := strings.LastIndexByte(lower, '/'); idx >= 0 {
+			if d := levenshtein(needle, lower[idx+1:]); d < distance {
+				distance = d
+			}
+		}
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{name, distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	// only the closest names are suggested
+	for _, c := range candidates {
+		if c.distance > candidates[0].distance {
+			break
+		}
+		result = append(result, c.name)
+	}
+	return limitStrings(result, maxZoneSuggestions)
+}
+
+func limitStrings(list []string, max int) []string {
+	if len(list) > max {
+		return list[:max]
+	}
+	return list
+}
+
+// levenshtein returns the edit distance of a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(first int, others ...int) int {
+	result := first
+	for _, i := range others {
+		if i < result {
+			result = i
+		}
+	}
+	return result
+}
+
+// knownZoneNames returns the sorted zone names of the first time zone database that is found, in the same
+// order of sources as time.LoadLocation: the ZONEINFO environment variable, the system directories and
+// the zoneinfo.zip of the Go installation. The names are only looked up once.
+func knownZoneNames() []string {
+	zoneNamesOnce.Do(func() {
+		sources := make([]string, 0, len(zoneInfoDirs)+2)
+		if zoneInfo := os.Getenv("ZONEINFO"); zoneInfo != "" {
+			sources = append(sources, zoneInfo)
+		}
+		sources = append(sources, zoneInfoDirs...)
+		sources = append(sources, filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"))
+
+		for _, source := range sources {
+			names := readZoneNames(source)
+			if len(names) > 0 {
+				sort.Strings(names)
+				zoneNames = append([]string{"UTC", "Local"}, names...)
+				return
+			}
+		}
+		zoneNames = []string{"UTC", "Local"}
+	})
+	return zoneNames
+}
+
+// readZoneNames returns the zone names of a zoneinfo directory or zip file.
+func readZoneNames(source string) []string {
+	fi, err := os.Stat(source)
+	if err != nil {
+		return nil
+	}
+	if !fi.IsDir() {
+		return readZipZoneNames(source)
+	}
+
+	names := make([]string, 0, 600)
+	_ = filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			// posix and right contain copies of the zones
+			if !isZoneName(rel) || rel == "posix" || rel == "right" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() && isZoneName(rel) && isZoneInfoFile(path) {
+			names = append(names, rel)
+		}
+		return nil
+	})
+	return names
+}
+
+func readZipZoneNames(source string) []string {
+	r, err := zip.OpenReader(source)
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+
+	names := make([]string, 0, len(r.File))
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, "/") && isZoneName(f.Name) {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// isZoneName filters files like zone.tab, leapseconds or posixrules that are no zones.
+func isZoneName(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if part == "" || part[0] < 'A' || part[0] > 'Z' || strings.Contains(part, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// isZoneInfoFile checks the magic bytes of the time zone file.
+func isZoneInfoFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return string(magic) == "TZif"
+}
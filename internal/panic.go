@@ -26,3 +26,15 @@ func PanicIfEmptyFloat(list []float64) {
 		panic("list must not be empty")
 	}
 }
+
+func PanicIfEmptyInt64(list []int64) {
+	if len(list) == 0 {
+		panic("list must not be empty")
+	}
+}
+
+func PanicIfEmptyUint64(list []uint64) {
+	if len(list) == 0 {
+		panic("list must not be empty")
+	}
+}
@@ -0,0 +1,46 @@
+package configo
+
+// Kind describes the kind of value that an option expects.
+// The kind defines how an option is represented as command line flag.
+// It is never inferred from the ParseFunction, options without any Kind are of kind KindString.
+type Kind int
+
+const (
+	// KindString is a flag that expects a value: --flag=value
+	KindString Kind = iota
+	// KindBool is a flag that does not need any value: --flag, --flag=false, --no-flag
+	KindBool
+	// KindList is a delimiter separated list of values.
+	KindList
+	// KindMap is a delimiter separated list of key value pairs.
+	KindMap
+	// KindChoice is a single value out of a fixed set of allowed values.
+	// It is passed like KindString. The allowed values are the Choices of the option.
+	KindChoice
+	// KindFile is a path to a file. It is passed like KindString and completed as file path.
+	KindFile
+	// KindDirectory is a path to a directory. It is passed like KindString and completed as directory path.
+	KindDirectory
+)
+
+// String returns the name of the kind
+func (k Kind) String() string {
+	switch k {
+	case KindString:
+		return "string"
+	case KindBool:
+		return "bool"
+	case KindList:
+		return "list"
+	case KindMap:
+		return "map"
+	case KindChoice:
+		return "choice"
+	case KindFile:
+		return "file"
+	case KindDirectory:
+		return "directory"
+	default:
+		return "unknown"
+	}
+}
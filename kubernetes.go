@@ -0,0 +1,146 @@
+package configo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidKubernetesName is returned when the name of a ConfigMap or Secret is not a valid
+	// DNS subdomain name as defined in RFC 1123.
+	ErrInvalidKubernetesName = errors.New("invalid kubernetes object name")
+
+	kubernetesNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	kubernetesKeyRegex  = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+)
+
+// KubernetesManifest renders key value maps, e.g. the result of Unparse or OptionDefaults,
+// as Kubernetes ConfigMap and Secret manifests.
+// Values of options that are marked as Secret are put into the Secret, base64 encoded,
+// all other values are put into the ConfigMap.
+// Values of options that cannot be set via environment variables are skipped, see Option.Sources.
+type KubernetesManifest struct {
+	Name       string            // name of the ConfigMap and default name of the Secret
+	SecretName string            // optional name of the Secret, defaults to Name
+	Namespace  string            // optional namespace of both objects
+	Labels     map[string]string // optional labels of both objects
+}
+
+// Marshal returns a multi document YAML manifest that contains the ConfigMap and the Secret.
+// The cfgs are used in order to determine which keys are secret.
+func (km *KubernetesManifest) Marshal(env map[string]string, cfgs ...Config) (string, error) {
+	configMap, err := km.ConfigMap(env, cfgs...)
+	if err != nil {
+		return "", err
+	}
+	secret, err := km.Secret(env, cfgs...)
+	if err != nil {
+		return "", err
+	}
+	return configMap + "---\n" + secret, nil
+}
+
+// ConfigMap returns a YAML manifest of a ConfigMap that contains all non-secret values of the env map.
+func (km *KubernetesManifest) ConfigMap(env map[string]string, cfgs ...Config) (string, error) {
+	secrets := SecretKeys(cfgs...)
+	data := make(map[string]string, len(env))
+	for k, v := range withoutSource(SourceEnv, env, cfgs...) {
+		if secrets[k] {
+			continue
+		}
+		if !utf8.ValidString(v) {
+			return "", fmt.Errorf("%w: kubernetes: value of key %s is not valid UTF-8", ErrInvalidFormatValue, k)
+		}
+		data[k] = v
+	}
+	return km.marshalObject("ConfigMap", km.Name, "", data)
+}
+
+// Secret returns a YAML manifest of an Opaque Secret that contains all secret values of the env map.
+func (km *KubernetesManifest) Secret(env map[string]string, cfgs ...Config) (string, error) {
+	secrets := SecretKeys(cfgs...)
+	data := make(map[string]string, len(secrets))
+	for k, v := range withoutSource(SourceEnv, env, cfgs...) {
+		if !secrets[k] {
+			continue
+		}
+		data[k] = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+	return km.marshalObject("Secret", km.secretName(), "Opaque", data)
+}
+
+// EnvFrom returns the envFrom YAML snippet that references the ConfigMap and the Secret
+// and that can be embedded in a container specification.
+func (km *KubernetesManifest) EnvFrom() string {
+	var sb strings.Builder
+	sb.WriteString("envFrom:\n")
+	sb.WriteString("  - configMapRef:\n")
+	sb.WriteString("      name: " + yamlQuote(km.Name) + "\n")
+	sb.WriteString("  - secretRef:\n")
+	sb.WriteString("      name: " + yamlQuote(km.secretName()) + "\n")
+	return sb.String()
+}
+
+func (km *KubernetesManifest) secretName() string {
+	if km.SecretName != "" {
+		return km.SecretName
+	}
+	return km.Name
+}
+
+func (km *KubernetesManifest) marshalObject(kind, name, objectType string, data map[string]string) (string, error) {
+	if len(name) > 253 || !kubernetesNameRegex.MatchString(name) {
+		return "", fmt.Errorf("%w: %s name: %q", ErrInvalidKubernetesName, kind, name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("apiVersion: v1\n")
+	sb.WriteString("kind: " + kind + "\n")
+	sb.WriteString("metadata:\n")
+	sb.WriteString("  name: " + yamlQuote(name) + "\n")
+	if km.Namespace != "" {
+		if len(km.Namespace) > 63 || !kubernetesNameRegex.MatchString(km.Namespace) || strings.Contains(km.Namespace, ".") {
+			return "", fmt.Errorf("%w: namespace: %q", ErrInvalidKubernetesName, km.Namespace)
+		}
+		sb.WriteString("  namespace: " + yamlQuote(km.Namespace) + "\n")
+	}
+	if len(km.Labels) > 0 {
+		sb.WriteString("  labels:\n")
+		for _, k := range sortedKeys(km.Labels) {
+			sb.WriteString("    " + yamlQuote(k) + ": " + yamlQuote(km.Labels[k]) + "\n")
+		}
+	}
+	if objectType != "" {
+		sb.WriteString("type: " + objectType + "\n")
+	}
+	if len(data) == 0 {
+		sb.WriteString("data: {}\n")
+		return sb.String(), nil
+	}
+
+	sb.WriteString("data:\n")
+	for _, k := range sortedKeys(data) {
+		if len(k) > 253 || !kubernetesKeyRegex.MatchString(k) {
+			return "", fmt.Errorf("%w: kubernetes: %q", ErrInvalidFormatKey, k)
+		}
+		sb.WriteString("  " + yamlQuote(k) + ": " + yamlQuote(data[k]) + "\n")
+	}
+	return sb.String(), nil
+}
+
+// yamlQuote returns a double quoted YAML string.
+// JSON strings are valid YAML double quoted scalars.
+func yamlQuote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	// encoding a string cannot fail
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
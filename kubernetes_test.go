@@ -0,0 +1,84 @@
+package configo_test
+
+import (
+	"errors"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+type kubernetesCfg struct {
+	host     string
+	password string
+}
+
+func (kc *kubernetesCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:           "DB_HOST",
+			DefaultValue:  "localhost",
+			ParseFunction: parsers.String(&kc.host),
+		},
+		{
+			Key:           "DB_PASSWORD",
+			DefaultValue:  "s3cr3t",
+			Secret:        true,
+			ParseFunction: parsers.String(&kc.password),
+		},
+	}
+}
+
+func TestKubernetesManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &kubernetesCfg{}
+	km := configo.KubernetesManifest{
+		Name:      "app",
+		Namespace: "prod",
+		Labels: map[string]string{
+			"app": "true",
+		},
+	}
+
+	manifest, err := km.Marshal(configo.OptionDefaults(cfg), cfg)
+	assert.NoError(err)
+	assert.Equal(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: "app"
+  namespace: "prod"
+  labels:
+    "app": "true"
+data:
+  "DB_HOST": "localhost"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: "app"
+  namespace: "prod"
+  labels:
+    "app": "true"
+type: Opaque
+data:
+  "DB_PASSWORD": "czNjcjN0"
+`, manifest)
+
+	km.SecretName = "app-secret"
+	assert.Equal(`envFrom:
+  - configMapRef:
+      name: "app"
+  - secretRef:
+      name: "app-secret"
+`, km.EnvFrom())
+
+	configMap, err := km.ConfigMap(map[string]string{"QUOTED": "a \"b\"\n"}, cfg)
+	assert.NoError(err)
+	assert.Contains(configMap, `"QUOTED": "a \"b\"\n"`)
+
+	km.Name = "Invalid_Name"
+	_, err = km.ConfigMap(map[string]string{}, cfg)
+	assert.True(errors.Is(err, configo.ErrInvalidKubernetesName))
+}
@@ -37,11 +37,37 @@ var (
 // some operation that relies on previously computed config values e.g. the construction of a file path that
 // needs a previously configured and evaluated directory path and some filename in order to construct that path.
 // INFO: A pseudo option enforces the execution of the parsing function, even if the corresponding key does not exist in e.g. the environment.
+// The Secret parameter marks the option's value as sensitive, e.g. a password or a token.
+// Secret values are put into Kubernetes Secrets instead of ConfigMaps and are encrypted by UnparseEnvFile.
+// The Kind defines how the option is represented as command line flag. Options without any Kind are
+// string flags that expect a value, e.g. parsers.Bool options need KindBool in order to be passed as --flag.
+// Options of kind KindList and KindMap accept repeated flags, e.g. --peer a --peer b or --label k=v --label k2=v2.
+// The repeated flag values are joined with the Delimiter before they are parsed. For maps, the '=' of each
+// flag value is replaced with the KeyValueDelimiter. Both should match the delimiters of the ParseFunction.
+// The FlagName overrides the flag name that is derived from the Key with the KeyToFlagNameTransformer.
+// The ShortFlag is an optional single character alias of the flag, e.g. "v" for -v. Boolean short flags
+// can be combined, e.g. -vq
+// The Choices are the allowed values of the option that are shown in the help text, completion scripts
+// and example env files. They are not inferred from the ParseFunction and should match its allowed values.
+// The Sources restrict which layers may supply the option's value, e.g. SourceFile | SourceEnv for secrets
+// that must not be visible in the process list. Values of other sources result in an ErrSourceNotAllowed error.
+// Options without any Sources allow all sources.
+// Hidden options are parsed like any other option but are not shown in the help text, completion scripts
+// and example env files, see IncludeHiddenOptions.
 type Option struct {
-	Key          string
-	Description  string
-	Mandatory    bool
-	DefaultValue string
+	Key               string
+	Description       string
+	Mandatory         bool
+	DefaultValue      string
+	Secret            bool
+	Kind              Kind
+	Delimiter         string
+	KeyValueDelimiter string
+	FlagName          string
+	ShortFlag         string
+	Choices           []string
+	Sources           Source
+	Hidden            bool
 
 	PreParseAction  ActionFunc
 	ParseFunction   ParserFunc
@@ -104,7 +130,12 @@ func (o *Option) Parse(m map[string]string) error {
 		// overwritten then
 		// pseudo options do not evaluate the value, but get the value from somewhere else other than the passed
 		// string map. They might prompt the user via the shell, read some file etc.
-		if err := tryParse(value, o.ParseFunction); err != nil {
+		// encrypted values are decrypted before they are passed to the ParseFunction.
+		decrypted, err := DecryptValue(o.Key, value)
+		if err != nil {
+			return fmt.Errorf("error in value of option '%s': %w", o.Key, err)
+		}
+		if err := tryParse(decrypted, o.ParseFunction); err != nil {
 			return fmt.Errorf("error in value of option '%s': %w", o.Key, err)
 		}
 	}
@@ -124,6 +155,12 @@ func (o *Option) Parse(m map[string]string) error {
 // that the returned value is not added to any map or that we do not want to unparse (serialize)
 // any values of this option struct.
 func (o *Option) Unparse() (string, error) {
+	return o.unparse(true)
+}
+
+// unparse serializes the option value, values that equal the default value are only skipped
+// in case that skipDefault is set.
+func (o *Option) unparse(skipDefault bool) (string, error) {
 
 	err := tryExecAction(o.PreUnparseAction)
 	if err != nil {
@@ -143,7 +180,7 @@ func (o *Option) Unparse() (string, error) {
 	}
 
 	// skip default values in order to keep the config file/env variables map small.
-	if value == o.DefaultValue {
+	if skipDefault && value == o.DefaultValue {
 		// allow user to manually decide whether to use or not to use the value
 		// this is important in the case that we do define a lot of sane default values
 		// in our application that do not necessarily need to be written to the config map
@@ -174,6 +211,7 @@ func (o *Option) String() string {
 		Description  string
 		Mandatory    bool
 		DefaultValue string
+		Secret       bool
 	}
 
 	so := SubOption{
@@ -181,6 +219,7 @@ func (o *Option) String() string {
 		Description:  o.Description,
 		Mandatory:    o.Mandatory,
 		DefaultValue: o.DefaultValue,
+		Secret:       o.Secret,
 	}
 
 	b, err := json.MarshalIndent(&so, " ", " ")
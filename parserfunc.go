@@ -20,6 +20,23 @@ var (
 // UnparseFunctions go back to creating a map[string]string from the previously parse configuration struct.
 type UnparserFunc func() (string, error)
 
+// InvalidChoiceError is returned by ParserFuncs that only allow a fixed set of values,
+// e.g. parsers.ChoiceString, in case that the value is not one of the allowed choices.
+// It contains the allowed choices of the ParserFunc.
+type InvalidChoiceError struct {
+	Value   string
+	Choices []string
+	Err     error
+}
+
+func (e *InvalidChoiceError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidChoiceError) Unwrap() error {
+	return e.Err
+}
+
 func tryParse(value string, f ParserFunc) error {
 	if f == nil {
 		return nil
@@ -0,0 +1,63 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+// ByteSize parses a human readable byte size like 512MiB, 1.5GB, 10k or plain bytes and
+// sets the passed out reference to the number of bytes.
+// SI units (k, kB, MB, GB, TB, PB, EB) are powers of 1000, IEC units (KiB, MiB, GiB, TiB, PiB, EiB)
+// are powers of 1024. Units are case insensitive.
+// An error is returned in case that the value is not a whole number of bytes or does not fit into an uint64.
+func ByteSize(out *uint64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		size, err := parseByteSize(value)
+		if err != nil {
+			return err
+		}
+		*out = size
+		return nil
+	}
+}
+
+// RangesByteSize restricts the byte size to a distinct list of min-max ranges in bytes, see ByteSize and RangesInt.
+func RangesByteSize(out *uint64, minMaxRanges ...uint64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+	internal.PanicIfEmptyUint64(minMaxRanges)
+
+	distinctRanges := NewDistinctRangeListUint64(minMaxRanges...)
+	allowed := make([]string, 0, len(distinctRanges.r))
+	for _, r := range distinctRanges.r {
+		allowed = append(allowed, fmt.Sprintf("[%s:%s]", internal.FormatByteSize(r.Min), internal.FormatByteSize(r.Max)))
+	}
+	allowedRanges := strings.Join(allowed, ", ")
+
+	return func(value string) error {
+		size, err := parseByteSize(value)
+		if err != nil {
+			return err
+		}
+
+		// value not allowed
+		if !distinctRanges.Contains(size) {
+			return fmt.Errorf("invalid value of type 'byte size' got: '%s', allowed ranges: %s", value, allowedRanges)
+		}
+
+		*out = size
+		return nil
+	}
+}
+
+func parseByteSize(value string) (uint64, error) {
+	size, err := internal.ParseByteSize(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value of type 'byte size': %s : %w", value, err)
+	}
+	return size, nil
+}
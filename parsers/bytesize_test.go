@@ -0,0 +1,82 @@
+package parsers_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/jxsl13/simple-configo/unparsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteSize(t *testing.T) {
+	var size uint64
+	parse := parsers.ByteSize(&size)
+
+	for value, want := range map[string]uint64{
+		"0":        0,
+		"1024":     1024,
+		"10k":      10000,
+		"512MiB":   512 << 20,
+		"512 mib":  512 << 20,
+		"1.5GB":    1500000000,
+		"1.5KiB":   1536,
+		"15EiB":    15 << 60,
+		"100B":     100,
+		"0.5kB":    500,
+		"2TB":      2e12,
+		"1.25 GiB": 1342177280,
+	} {
+		require.NoError(t, parse(value), value)
+		assert.Equal(t, want, size, value)
+	}
+
+	for _, value := range []string{"", "MiB", "-1", "1.5", "0.0001kB", "1XB", "1.2.3MB", "1e3"} {
+		assert.Error(t, parse(value), value)
+	}
+	err := parse("16EiB")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+}
+
+func TestByteSizeUnparser(t *testing.T) {
+	var size uint64
+	unparse := unparsers.ByteSize(&size)
+
+	for in, want := range map[uint64]string{
+		0:          "0B",
+		100:        "100B",
+		1000:       "1kB",
+		1024:       "1KiB",
+		1536:       "1536B",
+		536870912:  "512MiB",
+		1500000000: "1.5GB",
+		1342177280: "1280MiB",
+		15 << 60:   "15EiB",
+		123456789:  "123456789B",
+	} {
+		size = in
+		got, err := unparse()
+		require.NoError(t, err)
+		assert.Equal(t, want, got, in)
+
+		// round trip
+		var parsed uint64
+		require.NoError(t, parsers.ByteSize(&parsed)(got))
+		assert.Equal(t, in, parsed)
+	}
+}
+
+func TestRangesByteSize(t *testing.T) {
+	var size uint64
+	parse := parsers.RangesByteSize(&size, 1<<20, 1<<30)
+
+	require.NoError(t, parse("512MiB"))
+	assert.Equal(t, uint64(512<<20), size)
+
+	err := parse("2GiB")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allowed ranges: [1MiB:1GiB]")
+}
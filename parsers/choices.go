@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"fmt"
+	"math/bits"
 	"strconv"
 
 	configo "github.com/jxsl13/simple-configo"
@@ -27,7 +28,11 @@ func ChoiceString(out *string, allowed ...string) configo.ParserFunc {
 		// value not allowed
 		if !allowedSet[value] {
 			allowedList := setToSortedListString(allowedSet)
-			return fmt.Errorf("invalid value of type 'string' got: '%s', allowed: %v", value, allowedList)
+			return &configo.InvalidChoiceError{
+				Value:   value,
+				Choices: allowedList,
+				Err:     fmt.Errorf("invalid value of type 'string' got: '%s', allowed: %v", value, allowedList),
+			}
 		}
 
 		*out = value
@@ -52,13 +57,21 @@ func ChoiceInt(out *int, allowed ...int) configo.ParserFunc {
 	return func(value string) error {
 		i, err := strconv.Atoi(value)
 		if err != nil {
-			return fmt.Errorf("invalid value of type 'integer': %s : %w", value, err)
+			return &configo.InvalidChoiceError{
+				Value:   value,
+				Choices: intListToStringList(setToSortedListInt(allowedSet)),
+				Err:     fmt.Errorf("invalid value of type 'integer': %s : %w", value, err),
+			}
 		}
 
 		// value not allowed
 		if !allowedSet[i] {
 			allowedList := setToSortedListInt(allowedSet)
-			return fmt.Errorf("invalid value of type 'integer' got: '%s', allowed: %v", value, allowedList)
+			return &configo.InvalidChoiceError{
+				Value:   value,
+				Choices: intListToStringList(allowedList),
+				Err:     fmt.Errorf("invalid value of type 'integer' got: '%s', allowed: %v", value, allowedList),
+			}
 		}
 
 		*out = i
@@ -83,16 +96,234 @@ func ChoiceFloat(out *float64, bitSize int, allowed ...float64) configo.ParserFu
 	return func(value string) error {
 		f, err := strconv.ParseFloat(value, bitSize)
 		if err != nil {
-			return fmt.Errorf("invalid value of type 'float': %s : %w", value, err)
+			return &configo.InvalidChoiceError{
+				Value:   value,
+				Choices: floatListToStringList(setToSortedListFloat(allowedSet), bitSize),
+				Err:     fmt.Errorf("invalid value of type 'float': %s : %w", value, err),
+			}
 		}
 
 		// value not allowed
 		if !allowedSet[f] {
 			allowedList := setToSortedListFloat(allowedSet)
-			return fmt.Errorf("invalid value of type 'float' got: '%s', allowed: %v", value, allowedList)
+			return &configo.InvalidChoiceError{
+				Value:   value,
+				Choices: floatListToStringList(allowedList, bitSize),
+				Err:     fmt.Errorf("invalid value of type 'float' got: '%s', allowed: %v", value, allowedList),
+			}
 		}
 
 		*out = f
 		return nil
 	}
 }
+
+// ChoiceInt8 restricts the int8 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceInt8(out *int8, allowed ...int8) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, int64(choice))
+	}
+	choices := newSignedChoices(list, "int8", 8)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int8(i)
+		return nil
+	}
+}
+
+// ChoiceInt16 restricts the int16 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceInt16(out *int16, allowed ...int16) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, int64(choice))
+	}
+	choices := newSignedChoices(list, "int16", 16)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int16(i)
+		return nil
+	}
+}
+
+// ChoiceInt32 restricts the int32 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceInt32(out *int32, allowed ...int32) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, int64(choice))
+	}
+	choices := newSignedChoices(list, "int32", 32)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int32(i)
+		return nil
+	}
+}
+
+// ChoiceInt64 restricts the int64 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceInt64(out *int64, allowed ...int64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, int64(choice))
+	}
+	choices := newSignedChoices(list, "int64", 64)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int64(i)
+		return nil
+	}
+}
+
+// ChoiceUint restricts the uint value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceUint(out *uint, allowed ...uint) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, uint64(choice))
+	}
+	choices := newUnsignedChoices(list, "uint", strconv.IntSize)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint(i)
+		return nil
+	}
+}
+
+// ChoiceUint8 restricts the uint8 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceUint8(out *uint8, allowed ...uint8) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, uint64(choice))
+	}
+	choices := newUnsignedChoices(list, "uint8", 8)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint8(i)
+		return nil
+	}
+}
+
+// ChoiceUint16 restricts the uint16 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceUint16(out *uint16, allowed ...uint16) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, uint64(choice))
+	}
+	choices := newUnsignedChoices(list, "uint16", 16)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint16(i)
+		return nil
+	}
+}
+
+// ChoiceUint32 restricts the uint32 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceUint32(out *uint32, allowed ...uint32) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, uint64(choice))
+	}
+	choices := newUnsignedChoices(list, "uint32", 32)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint32(i)
+		return nil
+	}
+}
+
+// ChoiceUint64 restricts the uint64 value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceUint64(out *uint64, allowed ...uint64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, uint64(choice))
+	}
+	choices := newUnsignedChoices(list, "uint64", 64)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint64(i)
+		return nil
+	}
+}
+
+// ChoiceUintptr restricts the uintptr value to a given set of values
+// that are passed with the 'allowed' parameter.
+func ChoiceUintptr(out *uintptr, allowed ...uintptr) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(allowed))
+	for _, choice := range allowed {
+		list = append(list, uint64(choice))
+	}
+	choices := newUnsignedChoices(list, "uintptr", bits.UintSize)
+
+	return func(value string) error {
+		i, err := choices.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uintptr(i)
+		return nil
+	}
+}
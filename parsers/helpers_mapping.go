@@ -13,6 +13,22 @@ func intListToStringList(list []int) []string {
 	return result
 }
 
+func int64ListToStringList(list []int64) []string {
+	result := make([]string, 0, len(list))
+	for _, i := range list {
+		result = append(result, strconv.FormatInt(i, 10))
+	}
+	return result
+}
+
+func uint64ListToStringList(list []uint64) []string {
+	result := make([]string, 0, len(list))
+	for _, u := range list {
+		result = append(result, strconv.FormatUint(u, 10))
+	}
+	return result
+}
+
 func floatListToStringList(list []float64, bitSize int) []string {
 	result := make([]string, 0, len(list))
 	for _, f := range list {
@@ -54,6 +70,24 @@ func setToSortedListInt(a map[int]bool) (result []int) {
 	return
 }
 
+func setToSortedListInt64(a map[int64]bool) (result []int64) {
+	result = make([]int64, 0, len(a))
+	for k := range a {
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return
+}
+
+func setToSortedListUint64(a map[uint64]bool) (result []uint64) {
+	result = make([]uint64, 0, len(a))
+	for k := range a {
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return
+}
+
 func setToSortedListFloat(a map[float64]bool) (result []float64) {
 	result = make([]float64, 0, len(a))
 	for k := range a {
@@ -123,3 +123,239 @@ func binarySearchRangeInt(a []IntRange, x int) bool {
 	}
 	return false
 }
+
+// Int64Range is a representation of a range that has a lower and upper bound (Min, Max).
+// Min and Max are elements of the range.
+type Int64Range struct {
+	Min int64
+	Max int64
+}
+
+func (ir *Int64Range) String() string {
+	return fmt.Sprintf("[%d:%d]", ir.Min, ir.Max)
+}
+
+func (ir *Int64Range) Contains(i int64) bool {
+	return ir.Min <= i && i <= ir.Max
+}
+
+func (ir *Int64Range) Below(i int64) bool {
+	return ir.Max < i
+}
+
+func (ir *Int64Range) Above(i int64) bool {
+	return i < ir.Min
+}
+
+// sorting
+type byInt64Range []Int64Range
+
+func (a byInt64Range) Len() int      { return len(a) }
+func (a byInt64Range) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a byInt64Range) Less(i, j int) bool {
+	if a[i].Min == a[j].Min {
+		return a[i].Max < a[j].Max
+	}
+	return a[i].Min < a[j].Min
+}
+
+type DistinctRangeListInt64 struct {
+	r []Int64Range
+}
+
+func (d *DistinctRangeListInt64) Contains(i int64) bool {
+	return binarySearchRangeInt64(d.r, i)
+}
+
+func (d *DistinctRangeListInt64) String() string {
+	var sb strings.Builder
+	const expectedChars = 7
+	sb.Grow(expectedChars * len(d.r))
+
+	for idx, r := range d.r {
+		sb.WriteString(r.String())
+		if idx < len(d.r)-1 {
+			sb.WriteString(", ")
+		}
+	}
+	return sb.String()
+}
+
+func NewDistinctRangeListInt64(minMaxRanges ...int64) DistinctRangeListInt64 {
+	if len(minMaxRanges)%2 != 0 {
+		panic(fmt.Errorf("passed parameter list 'minMaxRanges' must contain an even number of parameters"))
+	}
+
+	rangesList := make([]Int64Range, 0, len(minMaxRanges)/2)
+
+	for i := 0; i < len(minMaxRanges); i += 2 {
+		min := minMaxRanges[i]
+		max := minMaxRanges[i+1]
+		if max < min {
+			min, max = max, min
+		}
+		rangesList = append(rangesList, Int64Range{Min: min, Max: max})
+	}
+
+	distinctList := make([]Int64Range, 0, len(rangesList)/2)
+	sort.Sort(byInt64Range(rangesList))
+	for idx := range rangesList {
+		currentRange := &rangesList[idx]
+		if idx == 0 {
+			distinctList = append(distinctList, *currentRange)
+			continue
+		}
+		lastProcessedRange := &distinctList[len(distinctList)-1]
+
+		// [1:12], [9:13]
+		// [1:12], [2:12]
+		if lastProcessedRange.Max >= currentRange.Min {
+			if lastProcessedRange.Max > currentRange.Max {
+				// skip, as element lies within previous range
+				continue
+			}
+			// expand previous range to a lager range than before
+			lastProcessedRange.Max = currentRange.Max
+			// skip current range after updating previously processed one
+			continue
+		}
+		distinctList = append(distinctList, *currentRange)
+	}
+
+	return DistinctRangeListInt64{distinctList}
+}
+
+// binarySearchRangeInt64 requires a sorted list of ranges
+func binarySearchRangeInt64(a []Int64Range, x int64) bool {
+	start := 0
+	end := len(a) - 1
+	for start <= end {
+		mid := (start + end) / 2
+		if a[mid].Contains(x) {
+			return true
+		} else if a[mid].Below(x) {
+			start = mid + 1
+		} else if a[mid].Above(x) {
+			end = mid - 1
+		}
+	}
+	return false
+}
+
+// Uint64Range is a representation of a range that has a lower and upper bound (Min, Max).
+// Min and Max are elements of the range.
+type Uint64Range struct {
+	Min uint64
+	Max uint64
+}
+
+func (ir *Uint64Range) String() string {
+	return fmt.Sprintf("[%d:%d]", ir.Min, ir.Max)
+}
+
+func (ir *Uint64Range) Contains(i uint64) bool {
+	return ir.Min <= i && i <= ir.Max
+}
+
+func (ir *Uint64Range) Below(i uint64) bool {
+	return ir.Max < i
+}
+
+func (ir *Uint64Range) Above(i uint64) bool {
+	return i < ir.Min
+}
+
+// sorting
+type byUint64Range []Uint64Range
+
+func (a byUint64Range) Len() int      { return len(a) }
+func (a byUint64Range) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a byUint64Range) Less(i, j int) bool {
+	if a[i].Min == a[j].Min {
+		return a[i].Max < a[j].Max
+	}
+	return a[i].Min < a[j].Min
+}
+
+type DistinctRangeListUint64 struct {
+	r []Uint64Range
+}
+
+func (d *DistinctRangeListUint64) Contains(i uint64) bool {
+	return binarySearchRangeUint64(d.r, i)
+}
+
+func (d *DistinctRangeListUint64) String() string {
+	var sb strings.Builder
+	const expectedChars = 7
+	sb.Grow(expectedChars * len(d.r))
+
+	for idx, r := range d.r {
+		sb.WriteString(r.String())
+		if idx < len(d.r)-1 {
+			sb.WriteString(", ")
+		}
+	}
+	return sb.String()
+}
+
+func NewDistinctRangeListUint64(minMaxRanges ...uint64) DistinctRangeListUint64 {
+	if len(minMaxRanges)%2 != 0 {
+		panic(fmt.Errorf("passed parameter list 'minMaxRanges' must contain an even number of parameters"))
+	}
+
+	rangesList := make([]Uint64Range, 0, len(minMaxRanges)/2)
+
+	for i := 0; i < len(minMaxRanges); i += 2 {
+		min := minMaxRanges[i]
+		max := minMaxRanges[i+1]
+		if max < min {
+			min, max = max, min
+		}
+		rangesList = append(rangesList, Uint64Range{Min: min, Max: max})
+	}
+
+	distinctList := make([]Uint64Range, 0, len(rangesList)/2)
+	sort.Sort(byUint64Range(rangesList))
+	for idx := range rangesList {
+		currentRange := &rangesList[idx]
+		if idx == 0 {
+			distinctList = append(distinctList, *currentRange)
+			continue
+		}
+		lastProcessedRange := &distinctList[len(distinctList)-1]
+
+		// [1:12], [9:13]
+		// [1:12], [2:12]
+		if lastProcessedRange.Max >= currentRange.Min {
+			if lastProcessedRange.Max > currentRange.Max {
+				// skip, as element lies within previous range
+				continue
+			}
+			// expand previous range to a lager range than before
+			lastProcessedRange.Max = currentRange.Max
+			// skip current range after updating previously processed one
+			continue
+		}
+		distinctList = append(distinctList, *currentRange)
+	}
+
+	return DistinctRangeListUint64{distinctList}
+}
+
+// binarySearchRangeUint64 requires a sorted list of ranges
+func binarySearchRangeUint64(a []Uint64Range, x uint64) bool {
+	start := 0
+	end := len(a) - 1
+	for start <= end {
+		mid := (start + end) / 2
+		if a[mid].Contains(x) {
+			return true
+		} else if a[mid].Below(x) {
+			start = mid + 1
+		} else if a[mid].Above(x) {
+			end = mid - 1
+		}
+	}
+	return false
+}
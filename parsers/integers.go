@@ -0,0 +1,397 @@
+package parsers
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+// Int8 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into an int8.
+func Int8(out *int8) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseSigned(value, "int8", 8)
+		if err != nil {
+			return err
+		}
+		*out = int8(i)
+		return nil
+	}
+}
+
+// Int16 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into an int16.
+func Int16(out *int16) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseSigned(value, "int16", 16)
+		if err != nil {
+			return err
+		}
+		*out = int16(i)
+		return nil
+	}
+}
+
+// Int32 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into an int32.
+func Int32(out *int32) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseSigned(value, "int32", 32)
+		if err != nil {
+			return err
+		}
+		*out = int32(i)
+		return nil
+	}
+}
+
+// Int64 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into an int64.
+func Int64(out *int64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseSigned(value, "int64", 64)
+		if err != nil {
+			return err
+		}
+		*out = int64(i)
+		return nil
+	}
+}
+
+// Uint parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into a uint.
+func Uint(out *uint) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseUnsigned(value, "uint", strconv.IntSize)
+		if err != nil {
+			return err
+		}
+		*out = uint(i)
+		return nil
+	}
+}
+
+// Uint8 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into a uint8.
+func Uint8(out *uint8) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseUnsigned(value, "uint8", 8)
+		if err != nil {
+			return err
+		}
+		*out = uint8(i)
+		return nil
+	}
+}
+
+// Uint16 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into a uint16.
+func Uint16(out *uint16) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseUnsigned(value, "uint16", 16)
+		if err != nil {
+			return err
+		}
+		*out = uint16(i)
+		return nil
+	}
+}
+
+// Uint32 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into a uint32.
+func Uint32(out *uint32) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseUnsigned(value, "uint32", 32)
+		if err != nil {
+			return err
+		}
+		*out = uint32(i)
+		return nil
+	}
+}
+
+// Uint64 parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into a uint64.
+func Uint64(out *uint64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseUnsigned(value, "uint64", 64)
+		if err != nil {
+			return err
+		}
+		*out = uint64(i)
+		return nil
+	}
+}
+
+// Uintptr parses a passed value and sets the passed out reference to the resulting value,
+// returns an error in case that the value is not an integer or does not fit into a uintptr.
+func Uintptr(out *uintptr) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		i, err := parseUnsigned(value, "uintptr", bits.UintSize)
+		if err != nil {
+			return err
+		}
+		*out = uintptr(i)
+		return nil
+	}
+}
+
+// IntegerBases wraps an integer parser, e.g. Int, Uint16 or RangesInt, and allows its values to be passed
+// with a base prefix: 0b1010, 0o755 or 0x1F. Underscores may separate digits: 1_000_000 or 0xFF_FF.
+// bases restricts the allowed bases, values without any prefix are base 10. In case that no bases are passed,
+// the bases 2, 8, 10 and 16 are allowed.
+// Unlike in Go, a leading zero does not denote an octal value, 0755 is a base 10 value.
+// The value is converted to base 10 before it is passed to the parser, which checks whether the value fits
+// into its type. Values that are no valid integers are passed to the parser as they are.
+func IntegerBases(parser configo.ParserFunc, bases ...int) configo.ParserFunc {
+	internal.PanicIfNil(parser)
+	if len(bases) == 0 {
+		bases = []int{2, 8, 10, 16}
+	}
+
+	allowedSet := make(map[int]bool, len(bases))
+	for _, base := range bases {
+		switch base {
+		case 2, 8, 10, 16:
+			allowedSet[base] = true
+		default:
+			panic(fmt.Sprintf("unsupported integer base: %d", base))
+		}
+	}
+
+	return func(value string) error {
+		base, decimal, ok := integerToDecimal(value)
+		if !ok {
+			return parser(value)
+		}
+
+		// value not allowed
+		if !allowedSet[base] {
+			return fmt.Errorf("invalid value '%s': base %d is not allowed, allowed bases: %v", value, base, setToSortedListInt(allowedSet))
+		}
+
+		err := parser(decimal)
+		if err != nil && decimal != value {
+			return fmt.Errorf("invalid value '%s': %w", value, err)
+		}
+		return err
+	}
+}
+
+// integerToDecimal returns the base of the integer value and its base 10 representation.
+// ok is false in case that the value is not a valid integer.
+func integerToDecimal(value string) (base int, decimal string, ok bool) {
+	sign, digits := "", value
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	base = 10
+	prefixed := false
+	if len(digits) > 2 {
+		switch digits[:2] {
+		case "0b", "0B":
+			base, prefixed = 2, true
+		case "0o", "0O":
+			base, prefixed = 8, true
+		case "0x", "0X":
+			base, prefixed = 16, true
+		}
+	}
+	if prefixed {
+		digits = digits[2:]
+	}
+
+	// underscores must separate digits or follow the base prefix
+	if digits == "" ||
+		strings.HasSuffix(digits, "_") ||
+		strings.Contains(digits, "__") ||
+		(!prefixed && strings.HasPrefix(digits, "_")) ||
+		strings.ContainsAny(digits, "+-") {
+		return 0, "", false
+	}
+
+	i, ok := new(big.Int).SetString(strings.ReplaceAll(digits, "_", ""), base)
+	if !ok {
+		return 0, "", false
+	}
+	if sign == "-" {
+		i.Neg(i)
+	}
+	return base, i.String(), true
+}
+
+// parseSigned parses a base 10 integer that fits into bitSize bits.
+func parseSigned(value, typeName string, bitSize int) (int64, error) {
+	i, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value of type '%s': %s : %w", typeName, value, err)
+	}
+	return i, nil
+}
+
+// parseUnsigned parses a base 10 unsigned integer that fits into bitSize bits.
+func parseUnsigned(value, typeName string, bitSize int) (uint64, error) {
+	u, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value of type '%s': %s : %w", typeName, value, err)
+	}
+	return u, nil
+}
+
+// signedChoices is the set of allowed values of a signed integer type.
+type signedChoices struct {
+	allowedSet map[int64]bool
+	typeName   string
+	bitSize    int
+}
+
+func newSignedChoices(allowed []int64, typeName string, bitSize int) *signedChoices {
+	internal.PanicIfEmptyInt64(allowed)
+
+	allowedSet := make(map[int64]bool, len(allowed))
+	for _, choice := range allowed {
+		allowedSet[choice] = true
+	}
+	return &signedChoices{allowedSet, typeName, bitSize}
+}
+
+// parse parses the value and checks whether it is one of the allowed values.
+func (sc *signedChoices) parse(value string) (int64, error) {
+	i, err := parseSigned(value, sc.typeName, sc.bitSize)
+	if err != nil {
+		return 0, &configo.InvalidChoiceError{
+			Value:   value,
+			Choices: int64ListToStringList(setToSortedListInt64(sc.allowedSet)),
+			Err:     err,
+		}
+	}
+
+	// value not allowed
+	if !sc.allowedSet[i] {
+		allowedList := setToSortedListInt64(sc.allowedSet)
+		return 0, &configo.InvalidChoiceError{
+			Value:   value,
+			Choices: int64ListToStringList(allowedList),
+			Err:     fmt.Errorf("invalid value of type '%s' got: '%s', allowed: %v", sc.typeName, value, allowedList),
+		}
+	}
+	return i, nil
+}
+
+// unsignedChoices is the set of allowed values of an unsigned integer type.
+type unsignedChoices struct {
+	allowedSet map[uint64]bool
+	typeName   string
+	bitSize    int
+}
+
+func newUnsignedChoices(allowed []uint64, typeName string, bitSize int) *unsignedChoices {
+	internal.PanicIfEmptyUint64(allowed)
+
+	allowedSet := make(map[uint64]bool, len(allowed))
+	for _, choice := range allowed {
+		allowedSet[choice] = true
+	}
+	return &unsignedChoices{allowedSet, typeName, bitSize}
+}
+
+// parse parses the value and checks whether it is one of the allowed values.
+func (uc *unsignedChoices) parse(value string) (uint64, error) {
+	u, err := parseUnsigned(value, uc.typeName, uc.bitSize)
+	if err != nil {
+		return 0, &configo.InvalidChoiceError{
+			Value:   value,
+			Choices: uint64ListToStringList(setToSortedListUint64(uc.allowedSet)),
+			Err:     err,
+		}
+	}
+
+	// value not allowed
+	if !uc.allowedSet[u] {
+		allowedList := setToSortedListUint64(uc.allowedSet)
+		return 0, &configo.InvalidChoiceError{
+			Value:   value,
+			Choices: uint64ListToStringList(allowedList),
+			Err:     fmt.Errorf("invalid value of type '%s' got: '%s', allowed: %v", uc.typeName, value, allowedList),
+		}
+	}
+	return u, nil
+}
+
+// signedRanges are the allowed ranges of a signed integer type.
+type signedRanges struct {
+	ranges   DistinctRangeListInt64
+	typeName string
+	bitSize  int
+}
+
+func newSignedRanges(minMaxRanges []int64, typeName string, bitSize int) *signedRanges {
+	internal.PanicIfEmptyInt64(minMaxRanges)
+	return &signedRanges{NewDistinctRangeListInt64(minMaxRanges...), typeName, bitSize}
+}
+
+// parse parses the value and checks whether it is within any of the allowed ranges.
+func (sr *signedRanges) parse(value string) (int64, error) {
+	i, err := parseSigned(value, sr.typeName, sr.bitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// value not allowed
+	if !sr.ranges.Contains(i) {
+		return 0, fmt.Errorf("invalid value of type '%s' got: '%s', allowed ranges: %s", sr.typeName, value, sr.ranges.String())
+	}
+	return i, nil
+}
+
+// unsignedRanges are the allowed ranges of an unsigned integer type.
+type unsignedRanges struct {
+	ranges   DistinctRangeListUint64
+	typeName string
+	bitSize  int
+}
+
+func newUnsignedRanges(minMaxRanges []uint64, typeName string, bitSize int) *unsignedRanges {
+	internal.PanicIfEmptyUint64(minMaxRanges)
+	return &unsignedRanges{NewDistinctRangeListUint64(minMaxRanges...), typeName, bitSize}
+}
+
+// parse parses the value and checks whether it is within any of the allowed ranges.
+func (ur *unsignedRanges) parse(value string) (uint64, error) {
+	u, err := parseUnsigned(value, ur.typeName, ur.bitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// value not allowed
+	if !ur.ranges.Contains(u) {
+		return 0, fmt.Errorf("invalid value of type '%s' got: '%s', allowed ranges: %s", ur.typeName, value, ur.ranges.String())
+	}
+	return u, nil
+}
@@ -0,0 +1,166 @@
+package parsers_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/jxsl13/simple-configo/unparsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegers(t *testing.T) {
+	var (
+		i8  int8
+		i16 int16
+		i32 int32
+		i64 int64
+		u   uint
+		u8  uint8
+		u16 uint16
+		u32 uint32
+		u64 uint64
+		ptr uintptr
+	)
+
+	tests := []struct {
+		name    string
+		parse   configo.ParserFunc
+		unparse configo.UnparserFunc
+		valid   []string
+		invalid []string
+	}{
+		{"int8", parsers.Int8(&i8), unparsers.Int8(&i8), []string{"-128", "0", "127"}, []string{"-129", "128", "1.5", "a"}},
+		{"int16", parsers.Int16(&i16), unparsers.Int16(&i16), []string{"-32768", "32767"}, []string{"-32769", "32768"}},
+		{"int32", parsers.Int32(&i32), unparsers.Int32(&i32), []string{"-2147483648", "2147483647"}, []string{"2147483648"}},
+		{"int64", parsers.Int64(&i64), unparsers.Int64(&i64), []string{"-9223372036854775808", "9223372036854775807"}, []string{"9223372036854775808"}},
+		{"uint", parsers.Uint(&u), unparsers.Uint(&u), []string{"0", strconv.FormatUint(math.MaxUint32, 10)}, []string{"-1"}},
+		{"uint8", parsers.Uint8(&u8), unparsers.Uint8(&u8), []string{"0", "255"}, []string{"-1", "256"}},
+		{"uint16", parsers.Uint16(&u16), unparsers.Uint16(&u16), []string{"0", "8080", "65535"}, []string{"65536", "-8080"}},
+		{"uint32", parsers.Uint32(&u32), unparsers.Uint32(&u32), []string{"4294967295"}, []string{"4294967296"}},
+		{"uint64", parsers.Uint64(&u64), unparsers.Uint64(&u64), []string{"18446744073709551615"}, []string{"18446744073709551616"}},
+		{"uintptr", parsers.Uintptr(&ptr), unparsers.Uintptr(&ptr), []string{"0", "4096"}, []string{"-1", "0x10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, value := range tt.valid {
+				require.NoError(t, tt.parse(value))
+				got, err := tt.unparse()
+				require.NoError(t, err)
+				assert.Equal(t, value, got)
+			}
+			for _, value := range tt.invalid {
+				err := tt.parse(value)
+				require.Error(t, err, value)
+				assert.Contains(t, err.Error(), "invalid value of type '"+tt.name+"'")
+			}
+		})
+	}
+}
+
+func TestChoiceIntegers(t *testing.T) {
+	var port uint16
+	parse := parsers.ChoiceUint16(&port, 443, 80, 8080)
+
+	require.NoError(t, parse("8080"))
+	assert.Equal(t, uint16(8080), port)
+
+	var choiceErr *configo.InvalidChoiceError
+	require.True(t, errors.As(parse("8443"), &choiceErr))
+	assert.Equal(t, []string{"80", "443", "8080"}, choiceErr.Choices)
+	require.True(t, errors.As(parse("65536"), &choiceErr))
+	assert.Contains(t, choiceErr.Error(), "value out of range")
+
+	var level int8
+	require.NoError(t, parsers.ChoiceInt8(&level, -1, 0, 1)("-1"))
+	assert.Equal(t, int8(-1), level)
+}
+
+func TestRangesIntegers(t *testing.T) {
+	var port uint16
+	parse := parsers.RangesUint16(&port, 1024, 49151, 8, 9)
+
+	require.NoError(t, parse("8080"))
+	assert.Equal(t, uint16(8080), port)
+	require.NoError(t, parse("9"))
+
+	err := parse("80")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allowed ranges: [8:9], [1024:49151]")
+	require.Error(t, parse("70000"))
+
+	var offset int64
+	parse = parsers.RangesInt64(&offset, math.MinInt64, -10, 10, math.MaxInt64)
+	require.NoError(t, parse("-9223372036854775808"))
+	assert.Equal(t, int64(math.MinInt64), offset)
+	require.Error(t, parse("0"))
+}
+
+func TestIntegerBases(t *testing.T) {
+	var i int
+	parse := parsers.IntegerBases(parsers.Int(&i))
+
+	for value, want := range map[string]int{
+		"0x1F":      31,
+		"0X_ff":     255,
+		"-0x10":     -16,
+		"0o755":     493,
+		"0b1010":    10,
+		"1_000_000": 1000000,
+		"0755":      755,
+		"+42":       42,
+	} {
+		require.NoError(t, parse(value), value)
+		assert.Equal(t, want, i, value)
+	}
+	for _, value := range []string{"0x", "1__000", "_1", "1_", "0xG", "0x-1", "1.5"} {
+		assert.Error(t, parse(value), value)
+	}
+
+	var mode uint16
+	parse = parsers.IntegerBases(parsers.Uint16(&mode), 8, 10)
+	require.NoError(t, parse("0o644"))
+	assert.Equal(t, uint16(0644), mode)
+	err := parse("0xFF")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "base 16 is not allowed, allowed bases: [8 10]")
+	err = parse("0o200000")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "value out of range")
+
+	// choice errors are preserved
+	var choiceErr *configo.InvalidChoiceError
+	parse = parsers.IntegerBases(parsers.ChoiceUint8(new(uint8), 1, 2))
+	require.True(t, errors.As(parse("0x10"), &choiceErr))
+	assert.Equal(t, []string{"1", "2"}, choiceErr.Choices)
+}
+
+func TestIntegerBaseUnparser(t *testing.T) {
+	var (
+		mask  uint64 = math.MaxUint64
+		mode  uint32 = 0755
+		flags int    = -10
+	)
+	tests := []struct {
+		unparse configo.UnparserFunc
+		want    string
+	}{
+		{unparsers.IntegerBase(unparsers.Uint64(&mask), 16), "0xffffffffffffffff"},
+		{unparsers.IntegerBase(unparsers.Uint32(&mode), 8), "0o755"},
+		{unparsers.IntegerBase(unparsers.Int(&flags), 2), "-0b1010"},
+		{unparsers.IntegerBase(unparsers.Int(&flags), 10), "-10"},
+	}
+	for _, tt := range tests {
+		got, err := tt.unparse()
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	var parsed uint64
+	require.NoError(t, parsers.IntegerBases(parsers.Uint64(&parsed))("0xffffffffffffffff"))
+	assert.Equal(t, mask, parsed)
+}
@@ -8,34 +8,6 @@ import (
 	"github.com/jxsl13/simple-configo/internal"
 )
 
-var (
-	// map of valid bool values that can be used in configs
-	boolValues = map[string]bool{
-		"0":        false,
-		"1":        true,
-		"true":     true,
-		"TRUE":     true,
-		"false":    false,
-		"FALSE":    false,
-		"enabled":  true,
-		"ENABLED":  true,
-		"disabled": false,
-		"DISABLED": false,
-		"y":        true,
-		"Y":        true,
-		"yes":      true,
-		"YES":      true,
-		"n":        false,
-		"N":        false,
-		"no":       false,
-		"NO":       false,
-		"enable":   true,
-		"ENABLE":   true,
-		"disable":  false,
-		"DISABLE":  false,
-	}
-)
-
 // String is the default function that returns a function
 // that sets the parsed value to the passed referenced variable
 // out is a pointer to the variable that gets the parsed value assigned to.
@@ -54,7 +26,7 @@ func Bool(out *bool) configo.ParserFunc {
 	internal.PanicIfNil(out)
 
 	return func(value string) error {
-		b, ok := boolValues[value]
+		b, ok := internal.ParseBool(value)
 		if !ok {
 			return fmt.Errorf("invalid value of type 'bool': %s", value)
 		}
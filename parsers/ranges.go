@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"fmt"
+	"math/bits"
 	"strconv"
 
 	configo "github.com/jxsl13/simple-configo"
@@ -37,3 +38,203 @@ func RangesInt(out *int, minMaxRanges ...int) configo.ParserFunc {
 		return nil
 	}
 }
+
+// RangesInt8 restricts the int8 value to a distinct list of min-max ranges, see RangesInt.
+func RangesInt8(out *int8, minMaxRanges ...int8) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, int64(bound))
+	}
+	ranges := newSignedRanges(list, "int8", 8)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int8(i)
+		return nil
+	}
+}
+
+// RangesInt16 restricts the int16 value to a distinct list of min-max ranges, see RangesInt.
+func RangesInt16(out *int16, minMaxRanges ...int16) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, int64(bound))
+	}
+	ranges := newSignedRanges(list, "int16", 16)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int16(i)
+		return nil
+	}
+}
+
+// RangesInt32 restricts the int32 value to a distinct list of min-max ranges, see RangesInt.
+func RangesInt32(out *int32, minMaxRanges ...int32) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, int64(bound))
+	}
+	ranges := newSignedRanges(list, "int32", 32)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int32(i)
+		return nil
+	}
+}
+
+// RangesInt64 restricts the int64 value to a distinct list of min-max ranges, see RangesInt.
+func RangesInt64(out *int64, minMaxRanges ...int64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]int64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, int64(bound))
+	}
+	ranges := newSignedRanges(list, "int64", 64)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = int64(i)
+		return nil
+	}
+}
+
+// RangesUint restricts the uint value to a distinct list of min-max ranges, see RangesInt.
+func RangesUint(out *uint, minMaxRanges ...uint) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, uint64(bound))
+	}
+	ranges := newUnsignedRanges(list, "uint", strconv.IntSize)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint(i)
+		return nil
+	}
+}
+
+// RangesUint8 restricts the uint8 value to a distinct list of min-max ranges, see RangesInt.
+func RangesUint8(out *uint8, minMaxRanges ...uint8) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, uint64(bound))
+	}
+	ranges := newUnsignedRanges(list, "uint8", 8)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint8(i)
+		return nil
+	}
+}
+
+// RangesUint16 restricts the uint16 value to a distinct list of min-max ranges, see RangesInt.
+func RangesUint16(out *uint16, minMaxRanges ...uint16) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, uint64(bound))
+	}
+	ranges := newUnsignedRanges(list, "uint16", 16)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint16(i)
+		return nil
+	}
+}
+
+// RangesUint32 restricts the uint32 value to a distinct list of min-max ranges, see RangesInt.
+func RangesUint32(out *uint32, minMaxRanges ...uint32) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, uint64(bound))
+	}
+	ranges := newUnsignedRanges(list, "uint32", 32)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint32(i)
+		return nil
+	}
+}
+
+// RangesUint64 restricts the uint64 value to a distinct list of min-max ranges, see RangesInt.
+func RangesUint64(out *uint64, minMaxRanges ...uint64) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, uint64(bound))
+	}
+	ranges := newUnsignedRanges(list, "uint64", 64)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uint64(i)
+		return nil
+	}
+}
+
+// RangesUintptr restricts the uintptr value to a distinct list of min-max ranges, see RangesInt.
+func RangesUintptr(out *uintptr, minMaxRanges ...uintptr) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	list := make([]uint64, 0, len(minMaxRanges))
+	for _, bound := range minMaxRanges {
+		list = append(list, uint64(bound))
+	}
+	ranges := newUnsignedRanges(list, "uintptr", bits.UintSize)
+
+	return func(value string) error {
+		i, err := ranges.parse(value)
+		if err != nil {
+			return err
+		}
+		*out = uintptr(i)
+		return nil
+	}
+}
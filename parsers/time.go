@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	configo "github.com/jxsl13/simple-configo"
@@ -23,3 +24,138 @@ func Duration(out *time.Duration) configo.ParserFunc {
 		return nil
 	}
 }
+
+// DurationDays parses a duration like Duration that may additionally contain the units d (24h) and w (7d),
+// e.g. 7d, 2w, 1w2d12h or 1.5d. ISO-8601 durations like P1DT2H, PT30M or P2W are accepted as well,
+// years and months are not supported, as they do not have a fixed duration.
+// Days are always 24 hours long, daylight saving time is not taken into account.
+func DurationDays(out *time.Duration) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		d, err := internal.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid value of type 'duration': %s : %w", value, err)
+		}
+		*out = d
+		return nil
+	}
+}
+
+// RangeDurationDays parses a duration like DurationDays and restricts it to the range from min through max.
+func RangeDurationDays(out *time.Duration, min, max time.Duration) configo.ParserFunc {
+	internal.PanicIfNil(out)
+	if max < min {
+		min, max = max, min
+	}
+
+	return func(value string) error {
+		d, err := internal.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid value of type 'duration': %s : %w", value, err)
+		}
+
+		// value not allowed
+		if d < min || max < d {
+			return fmt.Errorf("invalid value of type 'duration' got: '%s', allowed range: [%s:%s]",
+				value, internal.FormatDuration(min), internal.FormatDuration(max))
+		}
+
+		*out = d
+		return nil
+	}
+}
+
+// Time parses an absolute point in time like 2021-10-01T08:00:00Z and sets the passed out reference to it.
+// The value is parsed with the passed layouts in their order and afterwards with time.RFC3339.
+// Values without time zone information are UTC, see TimeInLocation.
+func Time(out *time.Time, layouts ...string) configo.ParserFunc {
+	internal.PanicIfNil(out)
+	return timeParser(out, time.UTC, false, layouts)
+}
+
+// TimeInLocation parses a point in time like Time, values without time zone information are
+// in the location loc. A nil loc is UTC.
+func TimeInLocation(out *time.Time, loc *time.Location, layouts ...string) configo.ParserFunc {
+	internal.PanicIfNil(out)
+	return timeParser(out, loc, false, layouts)
+}
+
+// TimeRelative parses a point in time like TimeInLocation and additionally accepts expressions that are relative
+// to the current time: now, now+2h or now-1d12h. The durations may contain days and weeks, see DurationDays.
+func TimeRelative(out *time.Time, loc *time.Location, layouts ...string) configo.ParserFunc {
+	internal.PanicIfNil(out)
+	return timeParser(out, loc, true, layouts)
+}
+
+func timeParser(out *time.Time, loc *time.Location, relative bool, layouts []string) configo.ParserFunc {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if !internal.Contains(layouts, time.RFC3339) {
+		layouts = append(layouts[:len(layouts):len(layouts)], time.RFC3339)
+	}
+
+	return func(value string) error {
+		if relative {
+			t, ok, err := parseRelativeTime(value, loc)
+			if err != nil {
+				return fmt.Errorf("invalid value of type 'time': %s : %w", value, err)
+			}
+			if ok {
+				*out = t
+				return nil
+			}
+		}
+
+		for _, layout := range layouts {
+			t, err := time.ParseInLocation(layout, value, loc)
+			if err == nil {
+				*out = t
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value of type 'time': %s : expected layouts: %s", value, strings.Join(layouts, ", "))
+	}
+}
+
+// parseRelativeTime parses now, now+<duration> and now-<duration>, spaces around the sign are allowed.
+// ok is false in case that the value is not a relative expression.
+func parseRelativeTime(value string, loc *time.Location) (t time.Time, ok bool, err error) {
+	expression := strings.TrimSpace(value)
+	if !strings.HasPrefix(expression, "now") {
+		return time.Time{}, false, nil
+	}
+	offset := strings.TrimSpace(strings.TrimPrefix(expression, "now"))
+	if offset != "" && offset[0] != '+' && offset[0] != '-' {
+		// e.g. a value of a custom layout
+		return time.Time{}, false, nil
+	}
+	now := time.Now().In(loc)
+	if offset == "" {
+		return now, true, nil
+	}
+
+	d, err := internal.ParseDuration(strings.ReplaceAll(offset, " ", ""))
+	if err != nil {
+		return time.Time{}, true, err
+	}
+	return now.Add(d), true, nil
+}
+
+// Location parses an IANA time zone name like Europe/Berlin, America/New_York, UTC or Local and sets the
+// passed out reference to the location. Fixed offsets like +02:00, -0530, +2 or UTC+2 are accepted as well
+// and are named after their normalized offset, e.g. +02:00.
+// In case that the zone is unknown, the error suggests similar zone names.
+func Location(out **time.Location) configo.ParserFunc {
+	internal.PanicIfNil(out)
+
+	return func(value string) error {
+		loc, err := internal.ParseLocation(value)
+		if err != nil {
+			return fmt.Errorf("invalid value of type 'location': %s : %w", value, err)
+		}
+		*out = loc
+		return nil
+	}
+}
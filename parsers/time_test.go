@@ -0,0 +1,201 @@
+package parsers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/jxsl13/simple-configo/unparsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationDays(t *testing.T) {
+	var d time.Duration
+	parse := parsers.DurationDays(&d)
+
+	for value, want := range map[string]time.Duration{
+		"0":          0,
+		"90m":        90 * time.Minute,
+		"7d":         7 * 24 * time.Hour,
+		"2w":         14 * 24 * time.Hour,
+		"1w2d12h":    9*24*time.Hour + 12*time.Hour,
+		"1.5d":       36 * time.Hour,
+		"-1d":        -24 * time.Hour,
+		"1d500ms":    24*time.Hour + 500*time.Millisecond,
+		"P1DT2H":     26 * time.Hour,
+		"PT30M":      30 * time.Minute,
+		"P2W":        14 * 24 * time.Hour,
+		"PT0,5S":     500 * time.Millisecond,
+		"-P1D":       -24 * time.Hour,
+		"P1DT1M1.5S": 24*time.Hour + time.Minute + 1500*time.Millisecond,
+	} {
+		require.NoError(t, parse(value), value)
+		assert.Equal(t, want, d, value)
+	}
+
+	for _, value := range []string{"", "d", "7", "7x", "1d-2h", "P", "PT", "P1DT", "P1Y", "P1M", "P1H", "100000w"} {
+		assert.Error(t, parse(value), value)
+	}
+}
+
+func TestRangeDurationDays(t *testing.T) {
+	var d time.Duration
+	parse := parsers.RangeDurationDays(&d, 24*time.Hour, 30*24*time.Hour)
+
+	require.NoError(t, parse("2w"))
+	assert.Equal(t, 14*24*time.Hour, d)
+
+	err := parse("5w")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allowed range: [1d:30d]")
+	require.Error(t, parse("12h"))
+}
+
+func TestDurationDaysUnparser(t *testing.T) {
+	var d time.Duration
+	unparse := unparsers.DurationDays(&d)
+
+	for in, want := range map[time.Duration]string{
+		0:                                    "0s",
+		14 * 24 * time.Hour:                  "2w",
+		10 * 24 * time.Hour:                  "10d",
+		36 * time.Hour:                       "1d12h",
+		26 * time.Hour:                       "1d2h",
+		228 * time.Hour:                      "9d12h",
+		100 * time.Hour:                      "4d4h",
+		90 * time.Minute:                     "1h30m",
+		1500 * time.Millisecond:              "1.5s",
+		500 * time.Millisecond:               "500ms",
+		-7 * 24 * time.Hour:                  "-1w",
+		24*time.Hour + 1500*time.Microsecond: "1d1.5ms",
+	} {
+		d = in
+		got, err := unparse()
+		require.NoError(t, err)
+		assert.Equal(t, want, got, in.String())
+
+		// round trip
+		var parsed time.Duration
+		require.NoError(t, parsers.DurationDays(&parsed)(got))
+		assert.Equal(t, in, parsed)
+	}
+}
+
+func TestTime(t *testing.T) {
+	var ts time.Time
+	parse := parsers.Time(&ts, "2006-01-02", "2006-01-02 15:04")
+
+	require.NoError(t, parse("2021-10-01T08:00:00+02:00"))
+	assert.True(t, ts.Equal(time.Date(2021, 10, 1, 6, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, parse("2021-10-01"))
+	assert.Equal(t, time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), ts)
+
+	require.NoError(t, parse("2021-10-01 08:30"))
+	assert.Equal(t, time.Date(2021, 10, 1, 8, 30, 0, 0, time.UTC), ts)
+
+	err := parse("01.10.2021")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected layouts: 2006-01-02, 2006-01-02 15:04, "+time.RFC3339)
+	require.Error(t, parse("now"))
+
+	got, err := unparsers.Time(&ts, "2006-01-02 15:04")()
+	require.NoError(t, err)
+	assert.Equal(t, "2021-10-01 08:30", got)
+	got, err = unparsers.Time(&ts)()
+	require.NoError(t, err)
+	assert.Equal(t, "2021-10-01T08:30:00Z", got)
+}
+
+func TestTimeInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	var ts time.Time
+	parse := parsers.TimeInLocation(&ts, loc, "2006-01-02 15:04")
+
+	require.NoError(t, parse("2021-10-01 08:30"))
+	assert.True(t, ts.Equal(time.Date(2021, 10, 1, 6, 30, 0, 0, time.UTC)))
+
+	// explicit time zones win
+	require.NoError(t, parse("2021-10-01T08:30:00Z"))
+	assert.True(t, ts.Equal(time.Date(2021, 10, 1, 8, 30, 0, 0, time.UTC)))
+}
+
+func TestTimeRelative(t *testing.T) {
+	var ts time.Time
+	parse := parsers.TimeRelative(&ts, nil)
+
+	before := time.Now()
+	require.NoError(t, parse("now"))
+	assert.WithinDuration(t, before, ts, time.Minute)
+	assert.Equal(t, time.UTC, ts.Location())
+
+	require.NoError(t, parse("now+2h"))
+	assert.WithinDuration(t, before.Add(2*time.Hour), ts, time.Minute)
+
+	require.NoError(t, parse("now - 1d12h"))
+	assert.WithinDuration(t, before.Add(-36*time.Hour), ts, time.Minute)
+
+	require.NoError(t, parse("2021-10-01T08:30:00Z"))
+	assert.Equal(t, time.Date(2021, 10, 1, 8, 30, 0, 0, time.UTC), ts)
+
+	require.Error(t, parse("now2h"))
+	require.Error(t, parse("now+2x"))
+	require.Error(t, parse("now -"))
+
+	// values of custom layouts that start with now are not relative expressions
+	parse = parsers.TimeRelative(&ts, nil, "nowhere 2006-01-02")
+	require.NoError(t, parse("nowhere 2021-10-01"))
+	assert.Equal(t, time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), ts)
+}
+
+func TestLocation(t *testing.T) {
+	var loc *time.Location
+	parse := parsers.Location(&loc)
+	unparse := unparsers.Location(&loc)
+
+	for value, want := range map[string]string{
+		"UTC":    "UTC",
+		"Local":  "Local",
+		"+02:00": "+02:00",
+		"-0530":  "-05:30",
+		"+2":     "+02:00",
+		"UTC-3":  "-03:00",
+	} {
+		require.NoError(t, parse(value), value)
+		got, err := unparse()
+		require.NoError(t, err)
+		assert.Equal(t, want, got, value)
+	}
+
+	require.NoError(t, parse("-05:30"))
+	_, offset := time.Date(2021, 10, 1, 0, 0, 0, 0, loc).Zone()
+	assert.Equal(t, -(5*60+30)*60, offset)
+
+	require.Error(t, parse("+15:00"))
+	require.Error(t, parse("+02:60"))
+
+	// boundaries
+	require.NoError(t, parse("-12:00"))
+	require.NoError(t, parse("+14:00"))
+	err := parse("-12:30")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected -12:00 to +14:00")
+	require.Error(t, parse("+14:01"))
+
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skip("time zone database is not available")
+	}
+	require.NoError(t, parse("Europe/Berlin"))
+	got, err := unparse()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", got)
+
+	err = parse("Europe/Berln")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid value of type 'location': Europe/Berln : unknown time zone, did you mean: Europe/Berlin")
+
+	err = parse("new york")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean: America/New_York")
+}
@@ -0,0 +1,220 @@
+package configo
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/joho/godotenv"
+)
+
+const redactedValue = "***"
+
+var (
+	// PrintConfigFlag enables the built-in --print-config flag. In case that the flag is passed, the configuration
+	// is parsed from all layers as usual, afterwards the effective configuration is printed to PrintConfigOutput
+	// and ErrPrintConfig is returned, which allows the application to exit.
+	// Every key is printed with its value and the layer that supplied it: default, file:<path>, env or flag.
+	// The values of secret options are redacted.
+	// The flag expects one of the formats env, json or table, e.g. --print-config=json
+	// For commands, the flag is a global flag of the root command.
+	PrintConfigFlag = false
+	// PrintConfigFlagName is the name of the built-in print config flag, see PrintConfigFlag.
+	PrintConfigFlagName = "print-config"
+	// PrintConfigOutput is the writer that the effective configuration is printed to, see PrintConfigFlag.
+	PrintConfigOutput io.Writer = os.Stdout
+
+	// ErrPrintConfig is returned after the effective configuration was printed, see PrintConfigFlag.
+	// Similar to flag.ErrHelp, the application should exit without any error.
+	ErrPrintConfig = errors.New("configuration printed")
+
+	printConfigFormats = []string{"env", "json", "table"}
+)
+
+// printConfigFlagOption is the pseudo option that represents the built-in print config flag in the help text.
+func printConfigFlagOption() Option {
+	return Option{
+		Description: "print the effective configuration and exit",
+		Kind:        KindChoice,
+		Choices:     printConfigFormats,
+		FlagName:    PrintConfigFlagName,
+	}
+}
+
+// definePrintConfigFlag defines the built-in print config flag.
+func definePrintConfigFlag(flags *flag.FlagSet) {
+	flags.Var(&printConfigFlagValue{}, PrintConfigFlagName, printConfigFlagOption().Description)
+}
+
+// printConfigFormat returns the format that was passed with the built-in print config flag.
+// An empty string is returned in case that the flag was not passed.
+func printConfigFormat(flags *flag.FlagSet) string {
+	f := flags.Lookup(PrintConfigFlagName)
+	if f == nil {
+		return ""
+	}
+	value, ok := f.Value.(*printConfigFlagValue)
+	if !ok {
+		return ""
+	}
+	return value.format
+}
+
+// printConfigFlagValue is the value of the built-in print config flag.
+type printConfigFlagValue struct {
+	format string
+}
+
+func (pcv *printConfigFlagValue) String() string {
+	if pcv == nil {
+		return ""
+	}
+	return pcv.format
+}
+
+func (pcv *printConfigFlagValue) Set(value string) error {
+	for _, format := range printConfigFormats {
+		if value == format {
+			pcv.format = value
+			return nil
+		}
+	}
+	return &InvalidChoiceError{
+		Value:   value,
+		Choices: printConfigFormats,
+		Err:     fmt.Errorf("invalid format '%s', expected one of: %s", value, strings.Join(printConfigFormats, ", ")),
+	}
+}
+
+// sourceLayers are the key value maps of all sources that were merged into the configuration.
+// fileProvenance contains the path of the env file that supplied the value of every key of the fileMap.
+type sourceLayers struct {
+	fileMap        map[string]string
+	fileProvenance map[string]string
+	env            map[string]string
+	flagMap        map[string]string
+}
+
+// source returns the layer that supplied the value of the key.
+func (sl *sourceLayers) source(key string) string {
+	if _, found := sl.flagMap[key]; found {
+		return "flag"
+	}
+	if _, found := sl.env[key]; found {
+		return "env"
+	}
+	if _, found := sl.fileMap[key]; found {
+		if filePath := sl.fileProvenance[key]; filePath != "" {
+			return "file:" + filePath
+		}
+		return "file"
+	}
+	return "default"
+}
+
+// value returns the value of the key from the highest layer that contains it.
+func (sl *sourceLayers) value(key string) (string, bool) {
+	for _, m := range []map[string]string{sl.flagMap, sl.env, sl.fileMap} {
+		if value, found := m[key]; found {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// effectiveValue is a single line of the printed configuration.
+type effectiveValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+// effectiveConfig returns the effective values of all options of the parsed configs.
+// The values are serialized with the UnparseFunction of the option. Options that do not have any
+// UnparseFunction or that skip unparsing show the value that was passed by the source instead.
+func effectiveConfig(layers sourceLayers, cfgs ...Config) ([]effectiveValue, error) {
+	unparsed, err := UnparseAll(cfgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]effectiveValue, 0, len(unparsed))
+	seen := make(map[string]bool, len(unparsed))
+	for _, cfg := range cfgs {
+		for _, opt := range cfg.Options() {
+			if !opt.IsOption() || seen[opt.Key] {
+				continue
+			}
+			seen[opt.Key] = true
+
+			value, found := unparsed[opt.Key]
+			if !found {
+				value, found = layers.value(opt.Key)
+			}
+			if !found {
+				value = opt.DefaultValue
+			}
+			if opt.Secret && value != "" {
+				value = redactedValue
+			}
+			result = append(result, effectiveValue{
+				Key:    opt.Key,
+				Value:  value,
+				Source: layers.source(opt.Key),
+				Secret: opt.Secret,
+			})
+		}
+	}
+	return result, nil
+}
+
+// printConfig prints the effective configuration in the format and returns ErrPrintConfig.
+func printConfig(format string, layers sourceLayers, cfgs ...Config) error {
+	values, err := effectiveConfig(layers, cfgs...)
+	if err != nil {
+		return err
+	}
+	err = writeEffectiveConfig(PrintConfigOutput, format, values)
+	if err != nil {
+		return err
+	}
+	return ErrPrintConfig
+}
+
+// writeEffectiveConfig writes the values in one of the printConfigFormats.
+func writeEffectiveConfig(w io.Writer, format string, values []effectiveValue) error {
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case "env":
+		for _, v := range values {
+			line, err := godotenv.Marshal(map[string]string{v.Key: v.Value})
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(w, "# source: %s\n%s\n", v.Source, line)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+		for _, v := range values {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Key, v.Value, v.Source)
+		}
+		return tw.Flush()
+	}
+}
@@ -0,0 +1,136 @@
+package configo_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/jxsl13/simple-configo/unparsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type printConfigCfg struct {
+	host     string
+	port     int
+	password string
+	name     string
+}
+
+func (pc *printConfigCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:             "PC_HOST",
+			DefaultValue:    "localhost",
+			ParseFunction:   parsers.String(&pc.host),
+			UnparseFunction: unparsers.String(&pc.host),
+		},
+		{
+			Key:             "PC_PORT",
+			DefaultValue:    "80",
+			ParseFunction:   parsers.Int(&pc.port),
+			UnparseFunction: unparsers.Int(&pc.port),
+		},
+		{
+			Key:             "PC_PASSWORD",
+			Secret:          true,
+			ParseFunction:   parsers.String(&pc.password),
+			UnparseFunction: unparsers.String(&pc.password),
+		},
+		{
+			// no unparse function
+			Key:           "PC_NAME",
+			DefaultValue:  "app",
+			ParseFunction: parsers.String(&pc.name),
+		},
+	}
+}
+
+// printConfig parses the config with the print config flag and returns the printed configuration.
+func printConfig(t *testing.T, format string) (string, string) {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), "app.env")
+	writeFiles(t, filepath.Dir(filePath), map[string]string{
+		"app.env": "PC_PASSWORD=secret\nPC_HOST=filehost\n",
+	})
+	require.NoError(t, os.Setenv("PC_HOST", "envhost"))
+	defer os.Unsetenv("PC_HOST")
+
+	var buf bytes.Buffer
+	configo.PrintConfigFlag = true
+	configo.PrintConfigOutput = &buf
+	defer func() {
+		configo.PrintConfigFlag = false
+		configo.PrintConfigOutput = os.Stdout
+	}()
+
+	args := os.Args
+	defer func() { os.Args = args }()
+	os.Args = []string{"app", "--print-config=" + format, "--pc-port", "8080"}
+
+	err := configo.ParseEnvFileOrEnvOrFlags(filePath, &printConfigCfg{})
+	require.ErrorIs(t, err, configo.ErrPrintConfig)
+	return buf.String(), filePath
+}
+
+func TestPrintConfig(t *testing.T) {
+	out, filePath := printConfig(t, "table")
+	assert.Equal(t, `KEY          VALUE    SOURCE
+PC_HOST      envhost  env
+PC_PORT      8080     flag
+PC_PASSWORD  ***      file:`+filePath+`
+PC_NAME      app      default
+`, out)
+
+	out, filePath = printConfig(t, "env")
+	assert.Equal(t, `# source: env
+PC_HOST="envhost"
+# source: flag
+PC_PORT="8080"
+# source: file:`+filePath+`
+PC_PASSWORD="***"
+# source: default
+PC_NAME="app"
+`, out)
+
+	out, filePath = printConfig(t, "json")
+	var values []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &values))
+	require.Len(t, values, 4)
+	assert.Equal(t, map[string]interface{}{"key": "PC_PASSWORD", "value": "***", "source": "file:" + filePath, "secret": true}, values[2])
+}
+
+func TestPrintConfigFlag(t *testing.T) {
+	configo.PrintConfigFlag = true
+	defer func() { configo.PrintConfigFlag = false }()
+
+	_, err := configo.GetFlagMap([]string{"--print-config=yaml"}, &printConfigCfg{})
+	require.Error(t, err)
+	assert.Contains(t, configo.Usage("app", &printConfigCfg{}), `
+General:
+      --print-config value  print the effective configuration and exit
+                            (choices: env, json, table)
+`)
+
+	_, err = configo.GetFlagMap([]string{}, &collisionCfg{configo.Options{
+		{Key: "PRINT_CONFIG", ParseFunction: parsers.String(new(string))},
+	}})
+	require.ErrorIs(t, err, configo.ErrFlagNameCollision)
+}
+
+func TestUnparseAll(t *testing.T) {
+	cfg := &printConfigCfg{}
+	require.NoError(t, configo.Parse(map[string]string{"PC_PORT": "8080"}, cfg))
+
+	env, err := configo.Unparse(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"PC_PORT": "8080"}, env)
+
+	env, err = configo.UnparseAll(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"PC_HOST": "localhost", "PC_PORT": "8080", "PC_PASSWORD": ""}, env)
+}
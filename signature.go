@@ -0,0 +1,180 @@
+package configo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+const (
+	// SignatureFileSuffix is appended to the env file path in order to construct the
+	// path of the sidecar signature file, e.g. .env -> .env.sig
+	SignatureFileSuffix = ".sig"
+
+	// trailing signature comment line: # configo-signature: hmac-sha256=<hex>
+	signatureCommentPrefix = "# configo-signature: hmac-sha256="
+)
+
+var (
+	// SignatureKeyEnvKey is the environment variable that contains the HMAC-SHA256 key
+	// that is used in order to sign and verify env files.
+	SignatureKeyEnvKey = "CONFIGO_SIGNATURE_KEY"
+	// SignatureRequired enforces that every env file that is read has a valid signature.
+	// If disabled, signatures are only verified in case that both the signature and the
+	// signature key are present.
+	SignatureRequired = false
+	// SignatureSidecar defines where the signature is written to when an env file is signed.
+	// false: as trailing comment line of the env file
+	// true: into a sidecar file with the SignatureFileSuffix
+	SignatureSidecar = false
+
+	// ErrMissingSignature is returned when an env file is required to be signed but is not.
+	ErrMissingSignature = errors.New("missing env file signature")
+	// ErrInvalidSignature is returned when the signature of an env file does not match its content.
+	ErrInvalidSignature = errors.New("invalid env file signature")
+	// ErrMissingSignatureKey is returned when an env file needs to be verified but the environment
+	// does not contain any signature key.
+	ErrMissingSignatureKey = errors.New("missing env file signature key")
+)
+
+// SignEnvFile signs the env file with the key from the SignatureKeyEnvKey environment variable.
+// Depending on SignatureSidecar the signature is either appended as trailing comment line or
+// written into a sidecar file.
+func SignEnvFile(filePathOrEnvKey string) error {
+	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
+	key := os.Getenv(SignatureKeyEnvKey)
+	if key == "" {
+		return fmt.Errorf("%w: %s is not set", ErrMissingSignatureKey, SignatureKeyEnvKey)
+	}
+
+	content, err := internal.Load(filePath)
+	if err != nil {
+		return err
+	}
+	content, _ = splitSignatureComment(content)
+	return writeSigned(filePath, content, key)
+}
+
+// VerifyEnvFile verifies the signature of the env file with the key from the
+// SignatureKeyEnvKey environment variable.
+// Contrary to reading env files, the signature is always required.
+func VerifyEnvFile(filePathOrEnvKey string) error {
+	filePath := getFilePathOrKey(GetEnv(), filePathOrEnvKey)
+	content, err := internal.Load(filePath)
+	if err != nil {
+		return err
+	}
+	_, err = verifiedEnvFileParts(filePath, content, true)
+	return err
+}
+
+// verifiedEnvFileParts strips the signature from the content, verifies it and parses the remaining content.
+func verifiedEnvFileParts(filePath, content string, required bool) ([]envFilePart, error) {
+	content, signature := splitSignatureComment(content)
+	if signature == "" {
+		sidecar, err := internal.Load(filePath + SignatureFileSuffix)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		signature = strings.TrimSpace(sidecar)
+	}
+
+	parts, err := parseEnvFileParts(content)
+	if err != nil {
+		return nil, err
+	}
+
+	key := os.Getenv(SignatureKeyEnvKey)
+	switch {
+	case signature == "" && required:
+		return nil, fmt.Errorf("%w: %s", ErrMissingSignature, filePath)
+	case signature == "":
+		return parts, nil
+	case key == "" && required:
+		return nil, fmt.Errorf("%w: %s is not set", ErrMissingSignatureKey, SignatureKeyEnvKey)
+	case key == "":
+		// cannot verify
+		return parts, nil
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, signEnvFileParts(parts, key)) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSignature, filePath)
+	}
+	return parts, nil
+}
+
+// writeSigned writes the content and its signature to the filePath.
+// An existing sidecar signature file is removed in case that the signature is written as trailing comment.
+func writeSigned(filePath, content, key string) error {
+	parts, err := parseEnvFileParts(content)
+	if err != nil {
+		return err
+	}
+	signature := hex.EncodeToString(signEnvFileParts(parts, key))
+
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if SignatureSidecar {
+		err = internal.WriteFileAtomic(filePath, []byte(content), 0666)
+		if err != nil {
+			return err
+		}
+		return internal.WriteFileAtomic(filePath+SignatureFileSuffix, []byte(signature+"\n"), 0666)
+	}
+
+	err = internal.WriteFileAtomic(filePath, []byte(content+signatureCommentPrefix+signature+"\n"), 0666)
+	if err != nil {
+		return err
+	}
+	return removeSignatureSidecar(filePath)
+}
+
+// removeSignatureSidecar removes the sidecar signature file of the env file in case that it exists.
+// Sidecar files of rewritten env files are outdated and would fail the verification.
+func removeSignatureSidecar(filePath string) error {
+	err := os.Remove(filePath + SignatureFileSuffix)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// splitSignatureComment removes the trailing signature comment line from the content
+// and returns the content without it as well as the signature.
+func splitSignatureComment(content string) (string, string) {
+	trimmed := strings.TrimRight(content, "\r\n\t ")
+	idx := strings.LastIndex(trimmed, "\n")
+	lastLine := strings.TrimSpace(trimmed[idx+1:])
+	if !strings.HasPrefix(lastLine, signatureCommentPrefix) {
+		return content, ""
+	}
+	return trimmed[:idx+1], strings.TrimPrefix(lastLine, signatureCommentPrefix)
+}
+
+// signEnvFileParts calculates the HMAC-SHA256 over the canonicalized content of an env file.
+// The parts are represented in the order of their appearance: the sorted key value pairs of every block
+// and the include directives, as their position relative to the keys defines which values win.
+// Every directive and every key value pair is quoted and written into a separate line.
+// This makes the signature independent of comments, quoting and the order of the keys within a block.
+func signEnvFileParts(parts []envFilePart, key string) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	for _, part := range parts {
+		if part.include != nil {
+			mac.Write([]byte(strconv.Quote(part.include.String()) + "\n"))
+			continue
+		}
+		for _, k := range sortedKeys(part.env) {
+			mac.Write([]byte(strconv.Quote(k) + "=" + strconv.Quote(part.env[k]) + "\n"))
+		}
+	}
+	return mac.Sum(nil)
+}
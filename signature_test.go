@@ -0,0 +1,111 @@
+package configo_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedEnvFile(t *testing.T) {
+	assert := assert.New(t)
+	setenv(t, configo.SignatureKeyEnvKey, "signing key")
+
+	filePath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, configo.UpdateEnvFile(map[string]string{"DB_HOST": "localhost"}, filePath))
+	assert.NoError(configo.VerifyEnvFile(filePath))
+
+	// re-signed after updating the file
+	require.NoError(t, configo.UpdateEnvFile(map[string]string{"DB_PORT": "5432"}, filePath))
+	assert.NoError(configo.VerifyEnvFile(filePath))
+
+	env, err := configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(map[string]string{"DB_HOST": "localhost", "DB_PORT": "5432"}, env)
+
+	// tamper with the file
+	b, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filePath, append([]byte("DB_USER=\"root\"\n"), b...), 0600))
+
+	_, err = configo.ReadEnvFile(filePath)
+	assert.True(errors.Is(err, configo.ErrInvalidSignature))
+	err = configo.ParseEnvFileOrEnv(filePath, &kubernetesCfg{})
+	assert.True(errors.Is(err, configo.ErrInvalidSignature))
+
+	// wrong key
+	require.NoError(t, configo.SignEnvFile(filePath))
+	setenv(t, configo.SignatureKeyEnvKey, "other key")
+	assert.True(errors.Is(configo.VerifyEnvFile(filePath), configo.ErrInvalidSignature))
+}
+
+func TestSignedEnvFileSidecar(t *testing.T) {
+	assert := assert.New(t)
+	setenv(t, configo.SignatureKeyEnvKey, "signing key")
+	configo.SignatureSidecar = true
+	defer func() { configo.SignatureSidecar = false }()
+
+	filePath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, configo.WriteEnvFile(map[string]string{"DB_HOST": "localhost"}, filePath))
+	assert.FileExists(filePath + configo.SignatureFileSuffix)
+	assert.NoError(configo.VerifyEnvFile(filePath))
+
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("DB_HOST=\"remote\"\n"), 0600))
+	assert.True(errors.Is(configo.VerifyEnvFile(filePath), configo.ErrInvalidSignature))
+}
+
+func TestSignatureRequired(t *testing.T) {
+	assert := assert.New(t)
+	configo.SignatureRequired = true
+	defer func() { configo.SignatureRequired = false }()
+
+	filePath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("DB_HOST=\"localhost\"\n"), 0600))
+
+	_, err := configo.ReadEnvFile(filePath)
+	assert.True(errors.Is(err, configo.ErrMissingSignature))
+
+	setenv(t, configo.SignatureKeyEnvKey, "signing key")
+	require.NoError(t, configo.SignEnvFile(filePath))
+	_, err = configo.ReadEnvFile(filePath)
+	assert.NoError(err)
+
+	setenv(t, configo.SignatureKeyEnvKey, "")
+	_, err = configo.ReadEnvFile(filePath)
+	assert.True(errors.Is(err, configo.ErrMissingSignatureKey))
+}
+
+func TestSignatureSidecarRewrite(t *testing.T) {
+	assert := assert.New(t)
+	setenv(t, configo.SignatureKeyEnvKey, "signing key")
+	configo.SignatureSidecar = true
+	defer func() { configo.SignatureSidecar = false }()
+
+	filePath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, configo.WriteEnvFile(map[string]string{"DB_HOST": "localhost"}, filePath))
+	assert.FileExists(filePath + configo.SignatureFileSuffix)
+
+	// unsigned rewrite removes the outdated sidecar
+	setenv(t, configo.SignatureKeyEnvKey, "")
+	require.NoError(t, configo.UpdateEnvFile(map[string]string{"DB_PORT": "5432"}, filePath))
+	assert.NoFileExists(filePath + configo.SignatureFileSuffix)
+
+	setenv(t, configo.SignatureKeyEnvKey, "signing key")
+	env, err := configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(map[string]string{"DB_HOST": "localhost", "DB_PORT": "5432"}, env)
+
+	// signed again, as trailing comment
+	require.NoError(t, configo.UpdateEnvFile(map[string]string{"DB_USER": "app"}, filePath))
+	require.NoError(t, configo.SignEnvFile(filePath))
+	assert.NoError(configo.VerifyEnvFile(filePath))
+
+	configo.SignatureSidecar = false
+	require.NoError(t, configo.UpdateEnvFile(map[string]string{"DB_USER": "root"}, filePath))
+	assert.NoFileExists(filePath + configo.SignatureFileSuffix)
+	assert.NoError(configo.VerifyEnvFile(filePath))
+}
@@ -0,0 +1,104 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Source is a layer that supplies option values.
+// Sources can be combined: SourceFile | SourceEnv
+type Source int
+
+const (
+	// SourceFile are env files, e.g. ParseEnvFile
+	SourceFile Source = 1 << iota
+	// SourceEnv are the environment variables of the process, e.g. ParseEnv
+	SourceEnv
+	// SourceFlag are the command line flags, e.g. ParseFlags
+	SourceFlag
+
+	// SourceAll allows all sources, which is the default for options without any Sources.
+	SourceAll = SourceFile | SourceEnv | SourceFlag
+)
+
+var (
+	// ErrSourceNotAllowed is returned when an option value is supplied by a source that the option does not allow.
+	ErrSourceNotAllowed = errors.New("value source not allowed")
+)
+
+// String returns the names of the sources, e.g. file|env
+func (s Source) String() string {
+	names := make([]string, 0, 3)
+	if s&SourceFile != 0 {
+		names = append(names, "file")
+	}
+	if s&SourceEnv != 0 {
+		names = append(names, "env")
+	}
+	if s&SourceFlag != 0 {
+		names = append(names, "flag")
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// allowsSource returns true in case that the option's value may be supplied by the source.
+func (o *Option) allowsSource(source Source) bool {
+	return o.Sources == 0 || o.Sources&source != 0
+}
+
+// checkSource returns an error in case that the env map contains a value of an option
+// that must not be supplied by the source.
+func checkSource(source Source, env map[string]string, cfgs ...Config) error {
+	for _, cfg := range cfgs {
+		for _, opt := range cfg.Options() {
+			if _, found := env[opt.Key]; found && !opt.allowsSource(source) {
+				return fmt.Errorf("%w: option '%s' must not be set via %s", ErrSourceNotAllowed, opt.Key, source)
+			}
+		}
+	}
+	return nil
+}
+
+// mergeSources checks the sources and merges them into a single map.
+// Environment values override file values and flag values override both of them.
+// nil maps are skipped.
+func mergeSources(fileMap, env, flagMap map[string]string, cfgs ...Config) (map[string]string, error) {
+	result := make(map[string]string, len(fileMap)+len(env)+len(flagMap))
+	for _, layer := range []struct {
+		source Source
+		env    map[string]string
+	}{
+		{SourceFile, fileMap},
+		{SourceEnv, env},
+		{SourceFlag, flagMap},
+	} {
+		if layer.env == nil {
+			continue
+		}
+		if err := checkSource(layer.source, layer.env, cfgs...); err != nil {
+			return nil, err
+		}
+		result = update(result, layer.env)
+	}
+	return result, nil
+}
+
+// withoutSource returns a copy of the env map without the values of options that do not allow the source.
+func withoutSource(source Source, env map[string]string, cfgs ...Config) map[string]string {
+	result := make(map[string]string, len(env))
+	for k, v := range env {
+		result[k] = v
+	}
+	for _, cfg := range cfgs {
+		for _, opt := range cfg.Options() {
+			if !opt.allowsSource(source) {
+				delete(result, opt.Key)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+package configo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/jxsl13/simple-configo/unparsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sourcesCfg struct {
+	password string
+	trace    bool
+	host     string
+}
+
+func (sc *sourcesCfg) Options() configo.Options {
+	return configo.Options{
+		{
+			Key:             "SRC_PASSWORD",
+			Description:     "database password",
+			Sources:         configo.SourceFile | configo.SourceEnv,
+			ParseFunction:   parsers.String(&sc.password),
+			UnparseFunction: unparsers.String(&sc.password),
+		},
+		{
+			Key:             "SRC_TRACE",
+			Description:     "trace every request",
+			DefaultValue:    "false",
+			Sources:         configo.SourceFlag,
+			Kind:            configo.KindBool,
+			ParseFunction:   parsers.Bool(&sc.trace),
+			UnparseFunction: unparsers.Bool(&sc.trace),
+		},
+		{
+			Key:             "SRC_HOST",
+			Description:     "database host",
+			ParseFunction:   parsers.String(&sc.host),
+			UnparseFunction: unparsers.String(&sc.host),
+		},
+	}
+}
+
+func TestSources(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"valid.env": "SRC_PASSWORD=file\nSRC_HOST=file\n",
+		"trace.env": "SRC_TRACE=true\n",
+	})
+	valid := filepath.Join(dir, "valid.env")
+	trace := filepath.Join(dir, "trace.env")
+
+	defer func(args []string) {
+		os.Args = args
+	}(os.Args)
+
+	os.Args = []string{"app", "--src-trace", "--src-host", "flag"}
+	cfg := &sourcesCfg{}
+	require.NoError(t, configo.ParseEnvFileOrEnvOrFlags(valid, cfg))
+	assert.Equal(t, "file", cfg.password)
+	assert.Equal(t, "flag", cfg.host)
+	assert.True(t, cfg.trace)
+
+	os.Args = []string{"app", "--src-password", "secret"}
+	err := configo.ParseEnvFileOrEnvOrFlags(valid, &sourcesCfg{})
+	require.ErrorIs(t, err, configo.ErrSourceNotAllowed)
+	assert.Contains(t, err.Error(), "option 'SRC_PASSWORD' must not be set via flag")
+
+	os.Args = []string{"app"}
+	err = configo.ParseEnvFileOrEnvOrFlags(trace, &sourcesCfg{})
+	require.ErrorIs(t, err, configo.ErrSourceNotAllowed)
+	assert.Contains(t, err.Error(), "option 'SRC_TRACE' must not be set via file")
+
+	setenv(t, "SRC_TRACE", "true")
+	err = configo.ParseEnv(&sourcesCfg{})
+	require.ErrorIs(t, err, configo.ErrSourceNotAllowed)
+	assert.Contains(t, err.Error(), "option 'SRC_TRACE' must not be set via env")
+}
+
+func TestSourcesNegatedFlag(t *testing.T) {
+	_, err := configo.GetFlagMap([]string{"--no-src-trace"}, &sourcesCfg{})
+	require.NoError(t, err)
+
+	cfg := &collisionCfg{configo.Options{
+		{Key: "DEBUG", Sources: configo.SourceEnv, Kind: configo.KindBool, ParseFunction: parsers.Bool(new(bool))},
+	}}
+	_, err = configo.GetFlagMap([]string{"--no-debug"}, cfg)
+	require.ErrorIs(t, err, configo.ErrSourceNotAllowed)
+}
+
+func TestSourcesHelpAndUnparse(t *testing.T) {
+	usage := configo.Usage("app", &sourcesCfg{})
+	assert.Contains(t, usage, "      SRC_PASSWORD      database password\n")
+	assert.Contains(t, usage, "(only via: file|env)")
+	assert.Contains(t, usage, "(only via: flag, default: false)")
+	assert.NotContains(t, usage, "--src-password")
+	assert.NotContains(t, usage, "env: SRC_TRACE")
+
+	script, err := configo.BashCompletion("app", &sourcesCfg{})
+	require.NoError(t, err)
+	assert.NotContains(t, script, "--src-password")
+	assert.Contains(t, script, "--src-trace")
+
+	cfg := &sourcesCfg{password: "secret", trace: true, host: "host"}
+	args, err := configo.UnparseFlags(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--src-trace=true", "--src-host=host"}, args)
+
+	filePath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, configo.UnparseEnvFile(filePath, cfg))
+	env, err := configo.ReadEnvFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"SRC_PASSWORD": "secret", "SRC_HOST": "host"}, env)
+
+	manifest, err := (&configo.KubernetesManifest{Name: "app"}).ConfigMap(map[string]string{"SRC_TRACE": "true", "SRC_HOST": "host"}, cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, manifest, "SRC_TRACE")
+}
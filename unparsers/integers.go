@@ -0,0 +1,47 @@
+package unparsers
+
+import (
+	"fmt"
+	"math/big"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/internal"
+)
+
+var (
+	integerBasePrefixes = map[int]string{
+		2:  "0b",
+		8:  "0o",
+		10: "",
+		16: "0x",
+	}
+)
+
+// IntegerBase wraps an integer unparser, e.g. Int or Uint32, and writes its value in the given base
+// with the prefix 0b for base 2, 0o for base 8 and 0x for base 16, e.g. 0xff for bit masks or 0o755 for modes.
+// The values can be parsed with parsers.IntegerBases.
+func IntegerBase(unparser configo.UnparserFunc, base int) configo.UnparserFunc {
+	internal.PanicIfNil(unparser)
+	prefix, ok := integerBasePrefixes[base]
+	if !ok {
+		panic(fmt.Sprintf("IntegerBase: unsupported integer base: %d", base))
+	}
+
+	return func() (string, error) {
+		value, err := unparser()
+		if err != nil {
+			return "", err
+		}
+
+		i, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return "", fmt.Errorf("invalid value of type 'integer': %s", value)
+		}
+		sign := ""
+		if i.Sign() < 0 {
+			sign = "-"
+			i.Neg(i)
+		}
+		return sign + prefix + i.Text(base), nil
+	}
+}
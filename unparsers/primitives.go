@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/internal"
 )
 
 // String returns a function that returns the string representation of the in value
@@ -40,6 +41,117 @@ func Int(in *int) configo.UnparserFunc {
 	}
 }
 
+// Int8 returns a function that returns the string representation of the in value
+func Int8(in *int8) configo.UnparserFunc {
+	if in == nil {
+		panic("Int8: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatInt(int64(*in), 10), nil
+	}
+}
+
+// Int16 returns a function that returns the string representation of the in value
+func Int16(in *int16) configo.UnparserFunc {
+	if in == nil {
+		panic("Int16: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatInt(int64(*in), 10), nil
+	}
+}
+
+// Int32 returns a function that returns the string representation of the in value
+func Int32(in *int32) configo.UnparserFunc {
+	if in == nil {
+		panic("Int32: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatInt(int64(*in), 10), nil
+	}
+}
+
+// Int64 returns a function that returns the string representation of the in value
+func Int64(in *int64) configo.UnparserFunc {
+	if in == nil {
+		panic("Int64: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatInt(int64(*in), 10), nil
+	}
+}
+
+// Uint returns a function that returns the string representation of the in value
+func Uint(in *uint) configo.UnparserFunc {
+	if in == nil {
+		panic("Uint: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatUint(uint64(*in), 10), nil
+	}
+}
+
+// Uint8 returns a function that returns the string representation of the in value
+func Uint8(in *uint8) configo.UnparserFunc {
+	if in == nil {
+		panic("Uint8: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatUint(uint64(*in), 10), nil
+	}
+}
+
+// Uint16 returns a function that returns the string representation of the in value
+func Uint16(in *uint16) configo.UnparserFunc {
+	if in == nil {
+		panic("Uint16: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatUint(uint64(*in), 10), nil
+	}
+}
+
+// Uint32 returns a function that returns the string representation of the in value
+func Uint32(in *uint32) configo.UnparserFunc {
+	if in == nil {
+		panic("Uint32: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatUint(uint64(*in), 10), nil
+	}
+}
+
+// Uint64 returns a function that returns the string representation of the in value
+func Uint64(in *uint64) configo.UnparserFunc {
+	if in == nil {
+		panic("Uint64: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatUint(uint64(*in), 10), nil
+	}
+}
+
+// Uintptr returns a function that returns the string representation of the in value
+func Uintptr(in *uintptr) configo.UnparserFunc {
+	if in == nil {
+		panic("Uintptr: nil pointer passed")
+	}
+	return func() (string, error) {
+		return strconv.FormatUint(uint64(*in), 10), nil
+	}
+}
+
+// ByteSize returns a function that returns the shortest exact human readable representation
+// of the in value, e.g. 512MiB or 1.5GB, which can be parsed with parsers.ByteSize.
+func ByteSize(in *uint64) configo.UnparserFunc {
+	if in == nil {
+		panic("ByteSize: nil pointer passed")
+	}
+	return func() (string, error) {
+		return internal.FormatByteSize(*in), nil
+	}
+}
+
 // Float returns a function that returns the string representation of the in value
 func Float(in *float64, bitSize int) configo.UnparserFunc {
 	if in == nil {
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/internal"
 )
 
 // Duration returns the string representation of the currently present value in the in pointer.
@@ -13,3 +14,41 @@ func Duration(in *time.Duration) configo.UnparserFunc {
 		return in.String(), nil
 	}
 }
+
+// DurationDays returns the representation of the duration with the largest units that may contain the units
+// d (24h) and w (7d), e.g. 2w, 10d, 9d12h, 1d12h or 1h30m, which can be parsed with parsers.DurationDays.
+func DurationDays(in *time.Duration) configo.UnparserFunc {
+	if in == nil {
+		panic("DurationDays: nil pointer passed")
+	}
+	return func() (string, error) {
+		return internal.FormatDuration(*in), nil
+	}
+}
+
+// Time returns the string representation of the point in time in the primary layout, which is
+// the first of the passed layouts or time.RFC3339 in case that no layouts are passed.
+// The layouts are usually the same that are passed to parsers.Time.
+func Time(in *time.Time, layouts ...string) configo.UnparserFunc {
+	if in == nil {
+		panic("Time: nil pointer passed")
+	}
+	layout := time.RFC3339
+	if len(layouts) > 0 {
+		layout = layouts[0]
+	}
+	return func() (string, error) {
+		return in.Format(layout), nil
+	}
+}
+
+// Location returns the name of the location, e.g. Europe/Berlin, UTC, Local or +02:00 for fixed offsets.
+// A nil location is UTC.
+func Location(in **time.Location) configo.UnparserFunc {
+	if in == nil {
+		panic("Location: nil pointer passed")
+	}
+	return func() (string, error) {
+		return (*in).String(), nil
+	}
+}
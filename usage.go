@@ -0,0 +1,252 @@
+package configo
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// the description column of the help text starts at most at this column
+	usageMaxColumn = 32
+	// minimal width of the description column
+	usageMinDescriptionWidth = 20
+)
+
+var (
+	// UsageWidth is the maximum line width of the help text that is rendered by Usage.
+	UsageWidth = 80
+	// IncludeHiddenOptions shows hidden options in the help text, completion scripts and example env files.
+	IncludeHiddenOptions = false
+)
+
+// Usage renders the help text of the flags that are derived from the options of the passed configs.
+// The flags are grouped by their config and sorted by their flag name. Configs that implement
+// a Name() string method are rendered with their name as group title.
+// Every flag shows its description, env key, default value, whether it is required and its allowed choices.
+// The default values of secret options are not shown.
+// The text is word-wrapped at UsageWidth.
+// The built-in global flags are documented in case that they are enabled, see ConfigFlag and PrintConfigFlag.
+func Usage(program string, cfgs ...Config) string {
+	return usage(program, true, cfgs...)
+}
+
+func usage(program string, globals bool, cfgs ...Config) string {
+	if program == "" {
+		program = filepath.Base(os.Args[0])
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Usage: " + program + " [flags]\n")
+	writeUsageGroups(&sb, globals, cfgs...)
+	return sb.String()
+}
+
+// writeUsageGroups writes the flags of the configs grouped by their config.
+// The enabled built-in global flags are written into a separate group in case that globals is set.
+func writeUsageGroups(sb *strings.Builder, globals bool, cfgs ...Config) {
+	groups := usageGroups(cfgs...)
+	if options := globalFlagOptions(); globals && len(options) > 0 {
+		groups = append([]usageGroup{{title: "General", options: options}}, groups...)
+	}
+	column := usageColumn(groups)
+	for _, g := range groups {
+		sb.WriteString("\n" + g.title + ":\n")
+		for _, opt := range g.options {
+			writeUsageOption(sb, opt, column)
+		}
+	}
+}
+
+// setUsage replaces the default usage output of the flag set, which is printed for -h and --help.
+func setUsage(flags *flag.FlagSet, program string, globals bool, cfgs ...Config) {
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage(program, globals, cfgs...))
+	}
+}
+
+// usageGroup contains the options of all configs that share the same title.
+type usageGroup struct {
+	title   string
+	options []Option
+}
+
+// usageGroups groups the flag options by the config that defines them first.
+func usageGroups(cfgs ...Config) []usageGroup {
+	options := make(map[string]Option)
+	for _, opt := range flagOptions(cfgs...) {
+		options[opt.Key] = opt
+	}
+
+	groups := make([]usageGroup, 0, len(cfgs))
+	indexes := make(map[string]int, len(cfgs))
+	seen := make(map[string]bool, len(options))
+	for _, cfg := range cfgs {
+		title := "Options"
+		if named, ok := cfg.(interface{ Name() string }); ok && named.Name() != "" {
+			title = named.Name()
+		}
+
+		idx, found := indexes[title]
+		if !found {
+			idx = len(groups)
+			indexes[title] = idx
+			groups = append(groups, usageGroup{title: title})
+		}
+
+		for _, opt := range cfg.Options() {
+			merged, ok := options[opt.Key]
+			if !ok || seen[opt.Key] || !merged.visible() {
+				continue
+			}
+			seen[opt.Key] = true
+			groups[idx].options = append(groups[idx].options, merged)
+		}
+	}
+
+	result := make([]usageGroup, 0, len(groups))
+	for _, g := range groups {
+		if len(g.options) == 0 {
+			continue
+		}
+		sort.SliceStable(g.options, func(i, j int) bool {
+			return g.options[i].flagName() < g.options[j].flagName()
+		})
+		result = append(result, g)
+	}
+	return result
+}
+
+// visible returns false for hidden options unless IncludeHiddenOptions is set.
+func (o *Option) visible() bool {
+	return !o.Hidden || IncludeHiddenOptions
+}
+
+// usageColumn returns the column at which the descriptions start.
+func usageColumn(groups []usageGroup) int {
+	column := 0
+	for _, g := range groups {
+		for _, opt := range g.options {
+			if l := len(usageFlag(opt)) + 2; l > column && l <= usageMaxColumn {
+				column = l
+			}
+		}
+	}
+	if column == 0 {
+		column = usageMaxColumn
+	}
+	return column
+}
+
+// usageFlag returns the flag column of an option, e.g. -v, --[no-]verbose
+// Options that cannot be passed as flag are represented by their key.
+func usageFlag(opt Option) string {
+	if !opt.allowsSource(SourceFlag) {
+		return "      " + opt.Key
+	}
+
+	var sb strings.Builder
+	if opt.ShortFlag != "" {
+		sb.WriteString("  -" + opt.ShortFlag + ", ")
+	} else {
+		sb.WriteString("      ")
+	}
+
+	switch opt.Kind {
+	case KindBool:
+		sb.WriteString("--[no-]" + opt.flagName())
+	case KindList:
+		sb.WriteString("--" + opt.flagName() + " list")
+	case KindMap:
+		sb.WriteString("--" + opt.flagName() + " map")
+	case KindFile:
+		sb.WriteString("--" + opt.flagName() + " file")
+	case KindDirectory:
+		sb.WriteString("--" + opt.flagName() + " directory")
+	default:
+		sb.WriteString("--" + opt.flagName() + " value")
+	}
+	return sb.String()
+}
+
+func writeUsageOption(sb *strings.Builder, opt Option, column int) {
+	width := UsageWidth - column
+	if width < usageMinDescriptionWidth {
+		width = usageMinDescriptionWidth
+	}
+
+	lines := make([]string, 0, 2)
+	if strings.TrimSpace(opt.Description) != "" {
+		lines = append(lines, wrapText(opt.Description, width)...)
+	}
+	if details := usageDetails(opt); details != "" {
+		lines = append(lines, wrapText(details, width)...)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "")
+	}
+
+	left := usageFlag(opt)
+	indent := strings.Repeat(" ", column)
+	if len(left)+2 > column {
+		// flag is too long, start the description in the next line
+		sb.WriteString(left + "\n")
+	} else {
+		sb.WriteString(left + strings.Repeat(" ", column-len(left)))
+		sb.WriteString(lines[0] + "\n")
+		lines = lines[1:]
+	}
+	for _, line := range lines {
+		sb.WriteString(indent + line + "\n")
+	}
+}
+
+// usageDetails returns the env key, the allowed sources, default value, mandatory marker and the allowed choices of an option.
+func usageDetails(opt Option) string {
+	details := make([]string, 0, 5)
+	if opt.Key != "" && opt.allowsSource(SourceEnv) && opt.allowsSource(SourceFlag) {
+		details = append(details, "env: "+opt.Key)
+	}
+	if opt.Sources != 0 && opt.Sources != SourceAll {
+		details = append(details, "only via: "+opt.Sources.String())
+	}
+	if opt.DefaultValue != "" && !opt.Secret {
+		details = append(details, "default: "+opt.DefaultValue)
+	}
+	if opt.Mandatory && opt.DefaultValue == "" {
+		details = append(details, "required")
+	}
+	if len(opt.Choices) > 0 {
+		details = append(details, "choices: "+strings.Join(opt.Choices, ", "))
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(details, ", ") + ")"
+}
+
+// wrapText splits the text into lines that are at most width characters long.
+// Words that are longer than width are put into separate lines.
+// Line breaks of the text are kept.
+func wrapText(text string, width int) []string {
+	lines := make([]string, 0, 1)
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case len(line)+1+len(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,126 @@
+package configo_test
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	configo "github.com/jxsl13/simple-configo"
+	"github.com/jxsl13/simple-configo/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type usageCfg struct {
+	verbose  bool
+	mode     string
+	level    int
+	password string
+	peers    []string
+}
+
+func (uc *usageCfg) Name() string {
+	return "Server"
+}
+
+func (uc *usageCfg) Options() configo.Options {
+	delimiter := ","
+	return configo.Options{
+		{
+			Key:           "VERBOSE",
+			Description:   "enable verbose logging",
+			DefaultValue:  "false",
+			ShortFlag:     "v",
+			Kind:          configo.KindBool,
+			ParseFunction: parsers.Bool(&uc.verbose),
+		},
+		{
+			Key:           "MODE",
+			Description:   "mode of operation of the server that is started by this application",
+			DefaultValue:  "fast",
+			Kind:          configo.KindChoice,
+			Choices:       []string{"fast", "slow"},
+			ParseFunction: parsers.ChoiceString(&uc.mode, "slow", "fast"),
+		},
+		{
+			Key:           "LEVEL",
+			Mandatory:     true,
+			Kind:          configo.KindChoice,
+			Choices:       []string{"1", "2", "3"},
+			ParseFunction: parsers.ChoiceInt(&uc.level, 3, 1, 2),
+		},
+		{
+			Key:           "PASSWORD",
+			Description:   "database password",
+			DefaultValue:  "secret",
+			Secret:        true,
+			ParseFunction: parsers.String(&uc.password),
+		},
+		{
+			Key:           "PEERS",
+			Kind:          configo.KindList,
+			Delimiter:     delimiter,
+			ParseFunction: parsers.List(&uc.peers, &delimiter),
+		},
+	}
+}
+
+func TestUsage(t *testing.T) {
+	want := `Usage: app [flags]
+
+Server:
+      --level value     (env: LEVEL, required, choices: 1, 2, 3)
+      --mode value      mode of operation of the server that is started by this
+                        application
+                        (env: MODE, default: fast, choices: fast, slow)
+      --password value  database password
+                        (env: PASSWORD)
+      --peers list      (env: PEERS)
+  -v, --[no-]verbose    enable verbose logging
+                        (env: VERBOSE, default: false)
+`
+	assert.Equal(t, want, configo.Usage("app", &usageCfg{}))
+}
+
+func TestUsageGroups(t *testing.T) {
+	got := configo.Usage("app", &boolFlagCfg{}, &usageCfg{})
+	assert.Contains(t, got, "\nOptions:\n      --[no-]debug")
+	assert.Contains(t, got, "\nServer:\n      --level value")
+}
+
+func TestUsageHelpFlag(t *testing.T) {
+	for _, arg := range []string{"-h", "--help"} {
+		t.Run(arg, func(t *testing.T) {
+			flags := configo.GetFlagSet("app", flag.ContinueOnError, &usageCfg{})
+			var buf bytes.Buffer
+			flags.SetOutput(&buf)
+
+			err := flags.Parse([]string{arg})
+			require.ErrorIs(t, err, flag.ErrHelp)
+			assert.Equal(t, configo.Usage("app", &usageCfg{}), buf.String())
+		})
+	}
+
+	_, err := configo.GetFlagMap([]string{"--help"}, &usageCfg{})
+	require.ErrorIs(t, err, flag.ErrHelp)
+}
+
+func TestUsageChoicesWithoutParsing(t *testing.T) {
+	parsed := false
+	parse := func(string) error {
+		parsed = true
+		return nil
+	}
+	cfg := &collisionCfg{configo.Options{
+		{Key: "MODE", Kind: configo.KindChoice, ParseFunction: parse},
+		{Key: "LEVEL", Kind: configo.KindChoice, Choices: []string{"low", "high"}, ParseFunction: parse},
+	}}
+
+	text := configo.Usage("app", cfg)
+	assert.Contains(t, text, "(env: LEVEL, choices: low, high)")
+	assert.Contains(t, text, "(env: MODE)")
+	script, err := configo.BashCompletion("app", cfg)
+	require.NoError(t, err)
+	assert.Contains(t, script, "choices=('low' 'high')")
+	assert.False(t, parsed)
+}